@@ -1,14 +1,18 @@
 package cmd
 
 import (
-	"os"
 	"path/filepath"
 
 	"github.com/nu12/action-docs/internal/action"
+	"github.com/nu12/action-docs/internal/export"
 	"github.com/nu12/action-docs/internal/helper"
+	"github.com/nu12/action-docs/internal/inject"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
+var defaultManifests = []string{"action.yml", "action.yaml"}
+
 var actionsCmd = &cobra.Command{
 	Use:   "actions",
 	Short: "Generate documentation for github actions",
@@ -16,17 +20,112 @@ var actionsCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		log.Info("Scanning actions")
 
-		files, err := helper.ScanPattern(actionsPath, "action.yml", true)
+		files, err := scanActions()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		f, err := format()
+		if err != nil {
+			log.Fatal(err)
+		}
+		opts, err := options()
+		if err != nil {
+			log.Fatal(err)
+		}
+		tmpl, err := loadTemplate("actions.template")
 		if err != nil {
 			log.Fatal(err)
 		}
 
+		doc := export.New()
 		for _, file := range files {
-			a := action.Parse(file, log)
+			a, diagnostics := action.Parse(file)
+			if report(diagnostics) {
+				continue
+			}
+			a.Options = opts
+			a.Repo = detectRepo(filepath.Dir(file))
+			if f == export.JSON {
+				doc.AddAction(a)
+				continue
+			}
+			readme := filepath.Dir(file) + "/README.md"
+			stamp, err := newStamp(filepath.Dir(file), []string{file}, export.New().AddAction(a))
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			if tmpl == nil {
+				err = writeSections(readme, a.Sections(), stamp)
+			} else {
+				var content string
+				if content, err = a.Render(tmpl, a.Repo); err == nil {
+					err = writeFile(readme, inject.Stamped(content, stamp))
+				}
+			}
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
 
-			if err := os.WriteFile(filepath.Dir(file)+"/README.md", []byte(a.Markdown()), 0644); err != nil {
+		if f == export.JSON {
+			if err := writeDocument(doc); err != nil {
 				log.Fatal(err)
 			}
 		}
+
+		finish()
 	},
 }
+
+// scanActions returns the action manifests selected by the configuration.
+func scanActions() ([]string, error) {
+	names := append(append([]string{}, defaultManifests...), viper.GetStringSlice("actions.manifests")...)
+	files, err := helper.ScanPattern(viper.GetString("actions.path"), names, true)
+	if err != nil {
+		return nil, err
+	}
+	files, err = helper.Filter(files, viper.GetString("actions.path"), viper.GetStringSlice("actions.include"), viper.GetStringSlice("actions.exclude"))
+	if err != nil {
+		return nil, err
+	}
+	return manifestPerDir(files, names), nil
+}
+
+// manifestPerDir keeps a single manifest for each directory, preferring the
+// earliest matching name, as every action writes the README of its directory.
+func manifestPerDir(files, names []string) []string {
+	priority := func(file string) int {
+		for i, name := range names {
+			if ok, _ := filepath.Match(name, filepath.Base(file)); ok {
+				return i
+			}
+		}
+		return len(names)
+	}
+
+	selected := map[string]string{}
+	var dirs []string
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		current, ok := selected[dir]
+		if !ok {
+			dirs = append(dirs, dir)
+		}
+		if !ok || priority(file) < priority(current) {
+			if ok {
+				log.Warning("Skipping " + current + ", " + file + " takes precedence")
+			}
+			selected[dir] = file
+			continue
+		}
+		log.Warning("Skipping " + file + ", " + current + " takes precedence")
+	}
+
+	result := []string{}
+	for _, dir := range dirs {
+		result = append(result, selected[dir])
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/nu12/action-docs/internal/action"
+	"github.com/nu12/action-docs/internal/diagnostic"
+	"github.com/nu12/action-docs/internal/lint"
+	"github.com/nu12/action-docs/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+var lintCmd = &cobra.Command{
+	Use:       "lint [actions|workflows]",
+	Short:     "Report undocumented or inconsistent action and workflow interfaces",
+	Long:      `Report undocumented or inconsistent action and workflow interfaces. Actions and workflows are selected with the same options as the actions and workflows commands, both are linted when no argument is given.`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"actions", "workflows"},
+	PreRun: func(cmd *cobra.Command, args []string) {
+		for _, kind := range lintKinds(args) {
+			bindFlags(kind+".", cmd.LocalFlags())
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		var diagnostics diagnostic.List
+		opts, err := options()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		kinds := lintKinds(args)
+		if slices.Contains(kinds, "actions") {
+			log.Info("Linting actions")
+			files, err := scanActions()
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, file := range files {
+				parsed, parseDiagnostics := action.Parse(file)
+				diagnostics = append(diagnostics, parseDiagnostics...)
+				if parseDiagnostics.HasErrors() {
+					continue
+				}
+				parsed.Options = opts
+				d, err := lint.Action(parsed)
+				if err != nil {
+					log.Fatal(err)
+				}
+				diagnostics = append(diagnostics, d...)
+			}
+		}
+
+		if slices.Contains(kinds, "workflows") {
+			log.Info("Linting workflows")
+			files, err := scanWorkflows()
+			if err != nil {
+				log.Fatal(err)
+			}
+			for _, file := range files {
+				parsed, parseDiagnostics := workflow.Parse(file)
+				diagnostics = append(diagnostics, parseDiagnostics...)
+				if parseDiagnostics.HasErrors() {
+					continue
+				}
+				parsed.Options = opts
+				d, err := lint.Workflow(parsed)
+				if err != nil {
+					log.Fatal(err)
+				}
+				diagnostics = append(diagnostics, d...)
+			}
+		}
+
+		for _, d := range diagnostics {
+			fmt.Println(d)
+		}
+		if diagnostics.HasErrors() {
+			os.Exit(1)
+		}
+	},
+}
+
+// lintKinds returns what the lint arguments select, both actions and workflows
+// by default.
+func lintKinds(args []string) []string {
+	if len(args) == 0 {
+		return []string{"actions", "workflows"}
+	}
+	return args
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+const errorf = "Error: %v. \nExpected: %v \nGot: %v"
+
+// runLint runs the lint command with args and returns what it printed. The
+// flags of previous runs are reset first.
+func runLint(t *testing.T, args ...string) string {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	lintCmd.Flags().VisitAll(func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			_ = s.Replace(nil)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	rootCmd.SetArgs(append([]string{"lint"}, args...))
+	err = rootCmd.Execute()
+	w.Close()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	return string(b)
+}
+
+func TestLintFlags(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		// Required inputs with a default are reported as warnings.
+		"build/action.yml": "name: Build\ndescription: Builds\ninputs:\n  target:\n    description: Target\n    required: true\n    default: linux\nruns:\n  using: node20\n  main: index.js\n",
+		"other/action.yml": "name: Other\ndescription: Other\ninputs:\n  target:\n    description: Target\n    required: true\n    default: linux\nruns:\n  using: node20\n  main: index.js\n",
+		// Workflows without a description are reported as warnings.
+		"workflows/ci.yml":     "name: CI\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n    - run: make test\n",
+		"workflows/deploy.yml": "name: Deploy\non: push\njobs:\n  deploy:\n    runs-on: ubuntu-latest\n    steps:\n    - run: make deploy\n",
+	}
+	for name, data := range files {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "Actions path",
+			args:     []string{"actions", "--path", filepath.Join(dir, "build")},
+			expected: []string{"action.yml:4:3: warning: input \"target\" is required but also has a default value [required-with-default]"},
+		},
+		{
+			name: "Workflows path",
+			args: []string{"workflows", "-p", filepath.Join(dir, "workflows")},
+			expected: []string{
+				"ci.yml:1:1: warning: workflow has no description [missing-description]",
+				"deploy.yml:1:1: warning: workflow has no description [missing-description]",
+			},
+		},
+		{
+			name:     "Exclude",
+			args:     []string{"workflows", "--path", filepath.Join(dir, "workflows"), "--exclude", "deploy.yml"},
+			expected: []string{"ci.yml:1:1: warning: workflow has no description [missing-description]"},
+		},
+		{
+			name:     "Include",
+			args:     []string{"actions", "--path", dir, "--include", "build/**"},
+			expected: []string{"action.yml:4:3: warning: input \"target\" is required but also has a default value [required-with-default]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, line := range strings.Split(strings.TrimSpace(runLint(t, tt.args...)), "\n") {
+				if line != "" {
+					got = append(got, filepath.Base(line))
+				}
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf(errorf, "Findings size mismatch", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf(errorf, "Finding doesn't match", tt.expected[i], got[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/nu12/action-docs/internal/diagnostic"
+	"github.com/nu12/action-docs/internal/diff"
+	"github.com/nu12/action-docs/internal/export"
+	"github.com/nu12/action-docs/internal/inject"
+	"github.com/nu12/action-docs/internal/markdown"
+	"github.com/nu12/action-docs/internal/render"
+	"github.com/nu12/action-docs/internal/repo"
+	"github.com/nu12/action-docs/internal/types"
+	"github.com/nu12/action-docs/internal/version"
+	"github.com/spf13/viper"
+)
+
+var staleFiles []string
+var parseErrors int
+
+// writeSections updates the generated regions of file, creating it when it
+// doesn't exist yet.
+func writeSections(file string, sections []*markdown.Section, stamp *inject.Stamp) error {
+	existing, err := readExisting(file)
+	if err != nil {
+		return err
+	}
+
+	content, err := inject.Render(existing, sections, viper.GetString("fallback"))
+	if err != nil {
+		return err
+	}
+	return writeFile(file, inject.Stamped(content, stamp))
+}
+
+// newStamp returns the stamp of a README generated from the sources, or nil
+// when stamps are disabled. Sources are relative to the README directory and
+// doc is what they were parsed into.
+func newStamp(dir string, sources []string, doc *export.Document) (*inject.Stamp, error) {
+	if !viper.GetBool("stamp") {
+		return nil, nil
+	}
+	hash, err := doc.Hash(dir)
+	if err != nil {
+		return nil, err
+	}
+	stamp := &inject.Stamp{Version: version.Get().Version, Hash: hash}
+	for _, source := range sources {
+		if rel, err := filepath.Rel(dir, source); err == nil {
+			source = rel
+		}
+		stamp.Sources = append(stamp.Sources, filepath.ToSlash(source))
+	}
+	return stamp, nil
+}
+
+// writeFile replaces the content of file. In check mode nothing is written and
+// a diff is printed when the file is out of date.
+func writeFile(file, content string) error {
+	if viper.GetBool("check") {
+		existing, err := readExisting(file)
+		if err != nil {
+			return err
+		}
+		if d := diff.Unified(existing, content, "a/"+file, "b/"+file); d != "" {
+			fmt.Print(d)
+			staleFiles = append(staleFiles, file)
+		}
+		return nil
+	}
+	return os.WriteFile(file, []byte(content), 0644)
+}
+
+func readExisting(file string) (string, error) {
+	existing, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", err
+	}
+	return string(existing), nil
+}
+
+// format returns the configured output format, failing on unknown ones.
+func format() (string, error) {
+	switch f := viper.GetString("format"); f {
+	case export.Markdown, export.JSON:
+		return f, nil
+	default:
+		return "", fmt.Errorf("invalid format %q, expected %q or %q", f, export.Markdown, export.JSON)
+	}
+}
+
+// options returns how the parsed items are documented, failing on unknown
+// settings.
+func options() (types.Options, error) {
+	o := types.Options{
+		DescribeWith: viper.GetString("describe-with"),
+		NewLine:      viper.GetString("table-newline"),
+		CodeDefaults: viper.GetInt("code-defaults"),
+	}
+	switch o.DescribeWith {
+	case types.DescribeDescription, types.DescribeComment, types.DescribeBoth:
+		return o, nil
+	default:
+		return o, fmt.Errorf("invalid describe-with %q, expected %q, %q or %q", o.DescribeWith, types.DescribeDescription, types.DescribeComment, types.DescribeBoth)
+	}
+}
+
+// repos caches the detected repositories by root, as reading the history of
+// large repositories is slow.
+var repos = map[string]repo.Info{}
+
+// detectRepo returns the repository containing dir, with the configured slug
+// and ref taking precedence over the detected ones.
+func detectRepo(dir string) repo.Info {
+	root := repo.Root(dir)
+	info, ok := repos[root]
+	if !ok {
+		info = repo.Detect(dir)
+		repos[root] = info
+		if info.Shallow && info.Ref == "" && viper.GetString("ref") == "" {
+			log.Warning("No tag found in the history of the shallow clone " + root + ", usage examples use main. Set --ref or fetch the tags")
+		}
+	}
+	if slug := viper.GetString("repository"); slug != "" {
+		info.Slug = slug
+	}
+	if ref := viper.GetString("ref"); ref != "" {
+		info.Ref = ref
+	}
+	return info
+}
+
+// writeDocument prints the JSON document, or writes it to the configured file.
+func writeDocument(d *export.Document) error {
+	content, err := d.JSON()
+	if err != nil {
+		return err
+	}
+	file := viper.GetString("json-output")
+	if file == "" {
+		fmt.Print(content)
+		return nil
+	}
+	return writeFile(file, content)
+}
+
+// loadTemplate parses the custom template configured under key, if any.
+func loadTemplate(key string) (*template.Template, error) {
+	file := viper.GetString(key)
+	if file == "" {
+		return nil, nil
+	}
+	return render.ParseFile(file)
+}
+
+// report prints diagnostics to stderr and reports whether any is an error.
+func report(diagnostics diagnostic.List) bool {
+	for _, d := range diagnostics {
+		fmt.Fprintln(os.Stderr, d)
+	}
+	if diagnostics.HasErrors() {
+		parseErrors++
+		return true
+	}
+	return false
+}
+
+// finish exits with a non-zero code when files failed to parse or when check
+// mode found stale files.
+func finish() {
+	code := 0
+	if parseErrors > 0 {
+		log.Error(fmt.Errorf("%d file(s) could not be parsed", parseErrors))
+		code = 1
+	}
+	if viper.GetBool("check") && len(staleFiles) > 0 {
+		log.Error(fmt.Errorf("%d file(s) out of date, run action-docs without --check to update them", len(staleFiles)))
+		code = 1
+	}
+	if code != 0 {
+		os.Exit(code)
+	}
+}
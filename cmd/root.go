@@ -6,15 +6,19 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/nu12/action-docs/internal/config"
+	"github.com/nu12/action-docs/internal/export"
+	"github.com/nu12/action-docs/internal/inject"
+	"github.com/nu12/action-docs/internal/types"
 	"github.com/nu12/go-logging"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
-var actionsPath string
 var cfgFile string
-var workflowsOutput string
 
 var log = logging.NewLogger()
 
@@ -37,34 +41,95 @@ func init() {
 	rootCmd.AddCommand(actionsCmd)
 	rootCmd.AddCommand(workflowsCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(lintCmd)
 
-	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.action-docs.yaml)")
+	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .action-docs.yaml in the current or any parent directory, and $HOME/.action-docs.yaml)")
 
-	actionsCmd.Flags().StringVarP(&actionsPath, "path", "p", ".", "Path to the directory containing github actions to be scanned")
-	workflowsCmd.Flags().StringVarP(&workflowsOutput, "output", "o", ".github/workflows", "Path to place the documentation for workflows")
+	rootCmd.PersistentFlags().Bool("check", false, "Don't write any file, print a diff and fail when the documentation is out of date")
+	rootCmd.PersistentFlags().String("fallback", inject.Overwrite, "What to do with an existing README without action-docs markers: overwrite or append")
+	rootCmd.PersistentFlags().StringP("format", "f", export.Markdown, "Output format: markdown writes README files, json prints the parsed model")
+	rootCmd.PersistentFlags().String("json-output", "", "File to write the json document to (default is stdout)")
+	rootCmd.PersistentFlags().String("describe-with", types.DescribeDescription, "What documents actions, workflows, inputs, outputs and secrets: description, comment (the YAML comment, when there is one) or both")
+	rootCmd.PersistentFlags().String("table-newline", "<br>", "Replacement for line breaks inside table cells")
+	rootCmd.PersistentFlags().Int("code-defaults", 0, "Render string default values longer than this many characters as code spans, 0 to disable")
+	rootCmd.PersistentFlags().Bool("stamp", false, "Record the action-docs version, the source files and a hash of their content in a comment of every generated README")
+	rootCmd.PersistentFlags().String("repository", "", "Repository of the usage examples, as owner/name (default is the origin remote of the git checkout)")
+	rootCmd.PersistentFlags().String("ref", "", "Version of the usage examples (default is the latest tag reachable from HEAD, or main)")
+	bindFlags("", rootCmd.PersistentFlags())
 
+	actionsCmd.Flags().StringP("path", "p", ".", "Path to the directory containing github actions to be scanned")
+	actionsCmd.Flags().StringSlice("manifest", []string{}, "Additional action manifest file names to scan for, besides action.yml and action.yaml")
+	actionsCmd.Flags().StringSlice("include", []string{}, "Only document actions whose manifest matches any of these globs")
+	actionsCmd.Flags().StringSlice("exclude", []string{}, "Skip actions whose manifest matches any of these globs")
+	actionsCmd.Flags().StringP("template", "t", "", "Go template file used to render the README of each action instead of the built-in layout")
+	bindFlags("actions.", actionsCmd.Flags())
+
+	workflowsCmd.Flags().StringP("path", "p", ".github/workflows", "Path to the directory containing github workflows to be scanned")
+	workflowsCmd.Flags().StringP("output", "o", "", "Path to place the documentation for workflows (default is --path)")
+	workflowsCmd.Flags().StringSlice("include", []string{}, "Only document workflows matching any of these globs")
+	workflowsCmd.Flags().StringSlice("exclude", []string{}, "Skip workflows matching any of these globs")
+	workflowsCmd.Flags().StringP("template", "t", "", "Go template file used to render the workflows README instead of the built-in layout")
+	workflowsCmd.Flags().Int("next-runs", 0, "Number of upcoming runs listed for each schedule")
+	workflowsCmd.Flags().String("reference-time", "", "RFC 3339 time the upcoming runs are computed from (default is now)")
+	bindFlags("workflows.", workflowsCmd.Flags())
+
+	// The lint flags apply to the actions or workflows settings, so they are
+	// bound when lint runs. Their path defaults are set here instead.
+	lintCmd.Flags().StringP("path", "p", "", "Path to the directory containing the actions or workflows to be scanned (default is . for actions and .github/workflows for workflows)")
+	lintCmd.Flags().StringSlice("manifest", []string{}, "Additional action manifest file names to scan for, besides action.yml and action.yaml")
+	lintCmd.Flags().StringSlice("include", []string{}, "Only lint actions and workflows matching any of these globs")
+	lintCmd.Flags().StringSlice("exclude", []string{}, "Skip actions and workflows matching any of these globs")
+	viper.SetDefault("actions.path", ".")
+	viper.SetDefault("workflows.path", ".github/workflows")
+
+	versionCmd.Flags().Bool("json", false, "Print the version information as JSON")
+}
+
+// flagKeys maps flags to config keys when their names differ.
+var flagKeys = map[string]string{
+	"manifest": "manifests",
+}
+
+// bindFlags makes every flag of the set available in viper under prefix,
+// so that values are resolved as flag > env var > config file > default.
+func bindFlags(prefix string, flags *pflag.FlagSet) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		if f.Name == "config" || f.Name == "help" {
+			return
+		}
+		key := f.Name
+		if k, ok := flagKeys[key]; ok {
+			key = k
+		}
+		cobra.CheckErr(viper.BindPFlag(prefix+key, f))
+	})
 }
 
-// initConfig reads in config file and ENV variables if set.
+// initConfig reads in config files and ENV variables if set.
 func initConfig() {
+	wd, err := os.Getwd()
+	cobra.CheckErr(err)
+
+	var files []string
 	if cfgFile != "" {
 		// Use config file from the flag.
-		viper.SetConfigFile(cfgFile)
+		files = []string{cfgFile}
 	} else {
-		// Find home directory.
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)
-
-		// Search config in home directory with name ".action-docs" (without extension).
-		viper.AddConfigPath(home)
-		viper.SetConfigType("yaml")
-		viper.SetConfigName(".action-docs")
+		// Repository config takes precedence over the one in the home directory.
+		home, _ := os.UserHomeDir()
+		files = config.Files(wd, home)
 	}
 
-	viper.AutomaticEnv() // read in environment variables that match
-
-	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+	for _, file := range files {
+		settings, err := config.Read(file, wd)
+		cobra.CheckErr(err)
+		cobra.CheckErr(viper.MergeConfigMap(settings))
+		fmt.Fprintln(os.Stderr, "Using config file:", file)
 	}
+
+	// Read in environment variables like ACTION_DOCS_WORKFLOWS_PATH.
+	viper.SetEnvPrefix("action_docs")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	viper.AutomaticEnv()
 }
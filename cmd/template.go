@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nu12/action-docs/internal/action"
+	"github.com/nu12/action-docs/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+var templateCmd = &cobra.Command{
+	Use:       "template [actions|workflows]",
+	Short:     "Print the built-in template",
+	Long:      `Print the built-in template, to be used as a starting point for a custom one`,
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{"actions", "workflows"},
+	Run: func(cmd *cobra.Command, args []string) {
+		switch args[0] {
+		case "actions":
+			fmt.Print(action.Template)
+		case "workflows":
+			fmt.Print(workflow.Template)
+		}
+	},
+}
@@ -1,16 +1,31 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 
+	"github.com/nu12/action-docs/internal/version"
 	"github.com/spf13/cobra"
 )
 
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show current version",
-	Long:  `Show current version`,
+	Long:  `Show the version, commit, build date and Go version of the binary`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("v1.0.0")
+		info := version.Get()
+		asJSON, err := cmd.Flags().GetBool("json")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !asJSON {
+			fmt.Print(info)
+			return
+		}
+		b, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(b))
 	},
 }
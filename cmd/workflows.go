@@ -1,12 +1,16 @@
 package cmd
 
 import (
-	"os"
+	"fmt"
+	"time"
 
+	"github.com/nu12/action-docs/internal/export"
 	"github.com/nu12/action-docs/internal/helper"
-	"github.com/nu12/action-docs/internal/markdown"
+	"github.com/nu12/action-docs/internal/inject"
+	"github.com/nu12/action-docs/internal/types"
 	"github.com/nu12/action-docs/internal/workflow"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 var workflowsCmd = &cobra.Command{
@@ -17,21 +21,118 @@ var workflowsCmd = &cobra.Command{
 		log.Info("Scanning workflows")
 		var ws = workflow.Workflows{
 			Workflows: []workflow.Workflow{},
-			Content:   markdown.List{},
 		}
 
-		files, err := helper.ScanPattern(".github/workflows", ".yml", false)
+		path := viper.GetString("workflows.path")
+		files, err := scanWorkflows()
 		if err != nil {
 			log.Fatal(err)
 		}
 
+		f, err := format()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opts, err := options()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if opts, err = scheduleOptions(opts); err != nil {
+			log.Fatal(err)
+		}
+		info := detectRepo(path)
+
 		for _, file := range files {
-			w := workflow.Parse(file, log)
+			w, diagnostics := workflow.Parse(file)
+			report(diagnostics)
+			w.Options = opts
+			w.Repo = info
 			ws.AddWorkflow(w)
 		}
+		if parseErrors > 0 {
+			// A partial README would drop the documentation of broken workflows.
+			finish()
+		}
+
+		doc := export.New()
+		for i := range ws.Workflows {
+			doc.AddWorkflow(&ws.Workflows[i])
+		}
+		if f == export.JSON {
+			if err := writeDocument(doc); err != nil {
+				log.Fatal(err)
+			}
+			finish()
+			return
+		}
+
+		output := viper.GetString("workflows.output")
+		if output == "" {
+			output = path
+		}
+		readme := output + "/README.md"
 
-		if err := os.WriteFile(workflowsOutput+"/README.md", []byte(ws.Markdown()), 0644); err != nil {
+		tmpl, err := loadTemplate("workflows.template")
+		if err != nil {
 			log.Fatal(err)
 		}
+		stamp, err := newStamp(output, files, doc)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if tmpl == nil {
+			err = writeWorkflows(readme, &ws, stamp)
+		} else {
+			var content string
+			if content, err = ws.Render(tmpl, info); err == nil {
+				err = writeFile(readme, inject.Stamped(content, stamp))
+			}
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		finish()
 	},
 }
+
+// writeWorkflows updates the generated regions of the workflows README. The
+// anchors of the table of contents are read from a first rendering, as
+// hand-written headings around the generated regions change their numbering.
+func writeWorkflows(readme string, ws *workflow.Workflows, stamp *inject.Stamp) error {
+	existing, err := readExisting(readme)
+	if err != nil {
+		return err
+	}
+	content, err := inject.Render(existing, ws.Sections(), viper.GetString("fallback"))
+	if err != nil {
+		return err
+	}
+	ws.Anchors = inject.Anchors(content)
+	return writeSections(readme, ws.Sections(), stamp)
+}
+
+// scanWorkflows returns the workflow files selected by the configuration.
+func scanWorkflows() ([]string, error) {
+	path := viper.GetString("workflows.path")
+	files, err := helper.ScanPattern(path, []string{"*.yml", "*.yaml"}, false)
+	if err != nil {
+		return nil, err
+	}
+	return helper.Filter(files, path, viper.GetStringSlice("workflows.include"), viper.GetStringSlice("workflows.exclude"))
+}
+
+// scheduleOptions adds the upcoming runs settings to o.
+func scheduleOptions(o types.Options) (types.Options, error) {
+	o.NextRuns = viper.GetInt("workflows.next-runs")
+	o.Now = time.Now()
+	if t := viper.GetString("workflows.reference-time"); t != "" {
+		now, err := time.Parse(time.RFC3339, t)
+		if err != nil {
+			return o, fmt.Errorf("invalid reference time %q, expected an RFC 3339 time such as 2024-01-01T00:00:00Z", t)
+		}
+		o.Now = now
+	}
+	return o, nil
+}
@@ -6,75 +6,105 @@ import (
 	"path/filepath"
 	"strconv"
 
+	"github.com/nu12/action-docs/internal/diagnostic"
 	"github.com/nu12/action-docs/internal/markdown"
+	"github.com/nu12/action-docs/internal/repo"
 	"github.com/nu12/action-docs/internal/types"
-	"github.com/nu12/go-logging"
 	"gopkg.in/yaml.v3"
 )
 
 type Action struct {
-	Name        string           `yaml:"name"`
-	Description string           `yaml:"description"`
-	Inputs      *types.InputMap  `yaml:"inputs"`
-	Outputs     *types.OutputMap `yaml:"outputs"`
-	Filename    string
+	Name        string           `yaml:"name" json:"name"`
+	Description string           `yaml:"description" json:"description"`
+	Inputs      *types.InputMap  `yaml:"inputs" json:"inputs"`
+	Outputs     *types.OutputMap `yaml:"outputs" json:"outputs"`
+	Runs        *Runs            `yaml:"runs" json:"runs,omitempty"`
+	Filename    string           `json:"file"`
+	Comment     string           `yaml:"-" json:"comment,omitempty"`
+	Options     types.Options    `yaml:"-" json:"-"`
+	Repo        repo.Info        `yaml:"-" json:"-"`
 }
 
 func (a *Action) Markdown() string {
+	s := ""
+	for _, section := range a.Sections() {
+		s += section.String()
+	}
+	return s
+}
+
+func (a *Action) Sections() []*markdown.Section {
 	inputs, outputs := a.getInputsOutputs()
-	md := &markdown.Markdown{}
-	md.Add(markdown.H1(a.Name)).
-		Add(markdown.P(a.Description)).
-		Add(markdown.H2("Usage example")).
-		Add(markdown.Code(fmt.Sprintf("jobs:\n  job-name:\n    runs-on: <runner>\n    steps:\n    - uses: %s@main\n%s", filepath.Dir(a.Filename), inputs.ToString(8))))
 
+	header := markdown.NewSection("header")
+	header.Add(markdown.H1(a.Name)).
+		Add(markdown.P(a.Options.Describe(a.Description, a.Comment)))
+
+	usage := markdown.NewSection("usage")
+	usage.Add(markdown.H2("Usage example")).
+		Add(markdown.Code(a.Usage()))
+
+	sInputs := markdown.NewSection("inputs")
 	if len(*inputs) > 0 {
-		md.Add(markdown.H2("Inputs"))
+		sInputs.Add(markdown.H2("Inputs"))
 
 		tInputs := markdown.Table{
-			Header: markdown.Header{"Name", "Description", "Required", "Default value"},
+			Header:   markdown.Header{"Name", "Description", "Required", "Default value"},
+			Verbatim: []int{3},
+			NewLine:  a.Options.NewLine,
 		}
 		for name, input := range *inputs {
-			tInputs.AddRow(markdown.Row{name, input.Description, strconv.FormatBool(input.Required), input.Default})
+			tInputs.AddRow(markdown.Row{name, a.Options.Describe(input.Description, input.Comment), strconv.FormatBool(input.Required), input.Default.Markdown(a.Options.CodeDefaults)})
 		}
 
-		md.Add(tInputs.Sort(0))
+		sInputs.Add(tInputs.Sort(0))
 	}
 
+	sOutputs := markdown.NewSection("outputs")
 	if len(*outputs) > 0 {
-		md.Add(markdown.H2("Outputs"))
+		sOutputs.Add(markdown.H2("Outputs"))
 
-		outputs := markdown.Table{
-			Header: markdown.Header{"Name", "Description"},
+		tOutputs := markdown.Table{
+			Header:  markdown.Header{"Name", "Description"},
+			NewLine: a.Options.NewLine,
 		}
-		for name, output := range *a.Outputs {
-			outputs.AddRow(markdown.Row{name, output.Description})
+		for name, output := range *outputs {
+			tOutputs.AddRow(markdown.Row{name, a.Options.Describe(output.Description, output.Comment)})
 		}
 
-		md.Add(outputs.Sort(0))
+		sOutputs.Add(tOutputs.Sort(0))
 	}
 
-	return md.String()
+	return []*markdown.Section{header, usage, sInputs, sOutputs, a.Runs.section(a.Options.NewLine), a.dependenciesSection()}
 }
 
-func Parse(file string, log *logging.Log) *Action {
+func (a *Action) Usage() string {
+	return fmt.Sprintf("jobs:\n  job-name:\n    runs-on: <runner>\n    steps:\n    - uses: %s\n%s", a.Repo.Uses(filepath.Dir(a.Filename)), a.getInputs().ToString(8))
+}
+
+// Parse reads an action manifest. Diagnostics are returned for unreadable or
+// invalid files, along with whatever could be decoded.
+func Parse(file string) (*Action, diagnostic.List) {
 	a := &Action{
-		Inputs:  &types.InputMap{},
-		Outputs: &types.OutputMap{},
+		Inputs:   &types.InputMap{},
+		Outputs:  &types.OutputMap{},
+		Filename: file,
 	}
 	b, err := os.ReadFile(file)
 	if err != nil {
-		log.Warning(err.Error())
-		return a
+		return a, diagnostic.FromError(file, err)
 	}
 
-	err = yaml.Unmarshal([]byte(b), a)
-	if err != nil {
-		log.Warning(err.Error())
+	var root yaml.Node
+	if err := yaml.Unmarshal(b, &root); err != nil {
+		return a, diagnostic.FromError(file, err)
+	}
+	a.Comment = types.FileComment(&root)
+	if err := root.Decode(a); err != nil {
+		return a, diagnostic.FromError(file, err)
 	}
-	a.Filename = file
 
-	return a
+	return a, nil
 }
 
 func (a *Action) getInputs() *types.InputMap {
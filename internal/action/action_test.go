@@ -2,11 +2,12 @@ package action
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/nu12/action-docs/internal/helper"
+	"github.com/nu12/action-docs/internal/repo"
 	"github.com/nu12/action-docs/internal/types"
-	"github.com/nu12/go-logging"
 )
 
 const (
@@ -52,7 +53,7 @@ outputs:
 			expectedInputs: &types.InputMap{
 				"datain1": {Description: "Input1 from data in", Required: true},
 				"datain2": {Description: "Input2 from data in", Required: false},
-				"datain3": {Description: "Input3 from data in", Default: "default value for datain3"},
+				"datain3": {Description: "Input3 from data in", Default: types.Default{Value: "default value for datain3", Kind: types.KindString}},
 			},
 			expectedOutputs: &types.OutputMap{
 				"dataout1": {Description: "Output from data out"},
@@ -81,7 +82,7 @@ inputs:
 			expectedInputs: &types.InputMap{
 				"datain4": {Description: "Input4 from data in", Required: true},
 				"datain5": {Description: "Input5 from data in", Required: false},
-				"datain6": {Description: "Input6 from data in", Default: "default value for datain6"},
+				"datain6": {Description: "Input6 from data in", Default: types.Default{Value: "default value for datain6", Kind: types.KindString}},
 			},
 			expectedOutputs: &types.OutputMap{},
 		},
@@ -100,7 +101,6 @@ description: 'Description of the empty action'
 		},
 	}
 
-	log := logging.NewLogger()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create temp file
@@ -114,7 +114,10 @@ description: 'Description of the empty action'
 			defer os.Remove(tmpFile)
 
 			// Parse and adjust filename
-			a := Parse(tmpFile, log)
+			a, diagnostics := Parse(tmpFile)
+			if len(diagnostics) > 0 {
+				t.Fatalf("error: %v", diagnostics)
+			}
 			a.Filename = tt.filename
 
 			// Check Name
@@ -149,6 +152,86 @@ description: 'Description of the empty action'
 			if helper.Hash(md) != tt.expectedHash {
 				t.Errorf(errorf, "Hash doesn't match", tt.expectedHash, helper.Hash(md))
 			}
+
+			// Built-in template matches the markdown
+			rendered, err := a.Render(nil, repo.Info{})
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if rendered != md {
+				t.Errorf(errorf, "Default template doesn't match", md, rendered)
+			}
+		})
+	}
+}
+
+func TestParseDiagnostics(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []string
+	}{
+		{
+			name:     "Invalid yaml",
+			data:     "name: 'Action'\ninputs: [\n",
+			expected: []string{"action.yml:2: error: did not find expected node content"},
+		},
+		{
+			name:     "Invalid input",
+			data:     "name: 'Action'\ninputs:\n  in1: 'not a map'\n  in2:\n    required: [true]\n",
+			expected: []string{"action.yml:3: error: cannot unmarshal !!str `not a map` into types.Input", "action.yml:5: error: cannot unmarshal !!seq into bool"},
+		},
+		{
+			name:     "Valid action",
+			data:     "name: 'Action'\n",
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			if err := os.WriteFile(tmp+"/"+filename, []byte(tt.data), 0644); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+
+			_, diagnostics := Parse(tmp + "/" + filename)
+			if len(diagnostics) != len(tt.expected) {
+				t.Fatalf(errorf, "Diagnostics size mismatch", tt.expected, diagnostics)
+			}
+			for i, d := range diagnostics {
+				d.File = filename
+				if d.String() != tt.expected[i] {
+					t.Errorf(errorf, "Diagnostic doesn't match", tt.expected[i], d.String())
+				}
+			}
+		})
+	}
+
+	_, diagnostics := Parse(t.TempDir() + "/missing.yml")
+	if !diagnostics.HasErrors() {
+		t.Errorf("error: %s", "Missing file should fail")
+	}
+}
+
+func TestUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		repo     repo.Info
+		expected string
+	}{
+		{name: "Unknown repository", file: "./actions/build/action.yml", expected: "    - uses: actions/build@main\n"},
+		{name: "Repository", file: "./actions/build/action.yml", repo: repo.Info{Slug: "acme/tools", Ref: "v1.2.3"}, expected: "    - uses: acme/tools/actions/build@v1.2.3\n"},
+		{name: "Root action", file: "action.yml", repo: repo.Info{Slug: "acme/tools"}, expected: "    - uses: acme/tools@main\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Action{Filename: tt.file, Inputs: &types.InputMap{}, Repo: tt.repo}
+			if got := a.Usage(); !strings.HasSuffix(got, tt.expected) {
+				t.Errorf(errorf, "Usage doesn't match", tt.expected, got)
+			}
 		})
 	}
 }
@@ -0,0 +1,99 @@
+package action
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nu12/action-docs/internal/markdown"
+)
+
+const (
+	Local      = "local"
+	Repository = "repository"
+	Docker     = "docker"
+)
+
+var (
+	commitSHA    = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	dockerDigest = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+)
+
+// Dependency is an action referenced by a `uses:` step of a composite action.
+type Dependency struct {
+	Uses   string
+	Kind   string
+	Action string
+	Ref    string
+	Pinned bool
+}
+
+func ParseDependency(uses string) Dependency {
+	d := Dependency{Uses: uses}
+	switch {
+	case strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "../"):
+		d.Kind = Local
+		d.Action = uses
+	case strings.HasPrefix(uses, "docker://"):
+		d.Kind = Docker
+		d.Action = strings.TrimPrefix(uses, "docker://")
+		if i := strings.LastIndex(d.Action, "@"); i >= 0 {
+			d.Action, d.Ref = d.Action[:i], d.Action[i+1:]
+		} else if i := strings.LastIndex(d.Action, ":"); i > strings.LastIndex(d.Action, "/") {
+			d.Action, d.Ref = d.Action[:i], d.Action[i+1:]
+		}
+		d.Pinned = dockerDigest.MatchString(d.Ref)
+	default:
+		d.Kind = Repository
+		d.Action = uses
+		if i := strings.LastIndex(uses, "@"); i >= 0 {
+			d.Action, d.Ref = uses[:i], uses[i+1:]
+		}
+		d.Pinned = commitSHA.MatchString(d.Ref)
+	}
+	return d
+}
+
+// Dependencies returns the unique actions used by the steps of a composite action.
+func (a *Action) Dependencies() []Dependency {
+	if a.Runs == nil || !a.Runs.IsComposite() {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	dependencies := []Dependency{}
+	for _, step := range a.Runs.Steps {
+		if step.Uses == "" || seen[step.Uses] {
+			continue
+		}
+		seen[step.Uses] = true
+		dependencies = append(dependencies, ParseDependency(step.Uses))
+	}
+	return dependencies
+}
+
+func (a *Action) dependenciesSection() *markdown.Section {
+	s := markdown.NewSection("dependencies")
+	dependencies := a.Dependencies()
+	if len(dependencies) == 0 {
+		return s
+	}
+
+	s.Add(markdown.H2("Dependencies"))
+
+	t := markdown.Table{
+		Header:   markdown.Header{"Action", "Type", "Ref", "Pinned to commit SHA"},
+		Verbatim: []int{0},
+		NewLine:  a.Options.NewLine,
+	}
+	for _, d := range dependencies {
+		pinned := "-"
+		if d.Kind != Local {
+			pinned = strconv.FormatBool(d.Pinned)
+		}
+		t.AddRow(markdown.Row{markdown.InlineCode(d.Action), d.Kind, d.Ref, pinned})
+	}
+	s.Add(t.Sort(0))
+
+	return s
+}
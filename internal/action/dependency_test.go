@@ -0,0 +1,89 @@
+package action
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseDependency(t *testing.T) {
+	tests := []struct {
+		name     string
+		uses     string
+		expected Dependency
+	}{
+		{
+			name:     "Local action",
+			uses:     "./actions/build",
+			expected: Dependency{Uses: "./actions/build", Kind: Local, Action: "./actions/build"},
+		},
+		{
+			name:     "Repository pinned to tag",
+			uses:     "actions/checkout@v4",
+			expected: Dependency{Uses: "actions/checkout@v4", Kind: Repository, Action: "actions/checkout", Ref: "v4"},
+		},
+		{
+			name:     "Repository path pinned to commit SHA",
+			uses:     "owner/repo/path@8f4b7f84864484a7bf31766abe9204da3cbe65b3",
+			expected: Dependency{Uses: "owner/repo/path@8f4b7f84864484a7bf31766abe9204da3cbe65b3", Kind: Repository, Action: "owner/repo/path", Ref: "8f4b7f84864484a7bf31766abe9204da3cbe65b3", Pinned: true},
+		},
+		{
+			name:     "Repository pinned to short SHA",
+			uses:     "owner/repo@8f4b7f8",
+			expected: Dependency{Uses: "owner/repo@8f4b7f8", Kind: Repository, Action: "owner/repo", Ref: "8f4b7f8"},
+		},
+		{
+			name:     "Docker image with tag",
+			uses:     "docker://alpine:3.20",
+			expected: Dependency{Uses: "docker://alpine:3.20", Kind: Docker, Action: "alpine", Ref: "3.20"},
+		},
+		{
+			name:     "Docker image with registry port",
+			uses:     "docker://localhost:5000/tool",
+			expected: Dependency{Uses: "docker://localhost:5000/tool", Kind: Docker, Action: "localhost:5000/tool"},
+		},
+		{
+			name:     "Docker image with digest",
+			uses:     "docker://alpine@sha256:1e42bbe2508154c9126d48c2b8a75420c3544343bf86fd041fb7527e017a4b4a",
+			expected: Dependency{Uses: "docker://alpine@sha256:1e42bbe2508154c9126d48c2b8a75420c3544343bf86fd041fb7527e017a4b4a", Kind: Docker, Action: "alpine", Ref: "sha256:1e42bbe2508154c9126d48c2b8a75420c3544343bf86fd041fb7527e017a4b4a", Pinned: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseDependency(tt.uses); got != tt.expected {
+				t.Errorf(errorf, "Dependency doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	data := `
+name: 'Composite action'
+runs:
+  using: 'composite'
+  steps:
+  - uses: 'actions/checkout@v4'
+  - uses: './actions/setup'
+  - run: 'make'
+    shell: 'bash'
+  - uses: 'actions/checkout@v4'
+`
+	expected := "## Dependencies\n\n|Action|Type|Ref|Pinned to commit SHA|\n|---|---|---|---|\n|`./actions/setup`|local||-|\n|`actions/checkout`|repository|v4|false|\n\n"
+
+	tmpFile := t.TempDir() + "/" + filename
+	if err := os.WriteFile(tmpFile, []byte(data), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	a, diagnostics := Parse(tmpFile)
+	if len(diagnostics) > 0 {
+		t.Fatalf("error: %v", diagnostics)
+	}
+	if len(a.Dependencies()) != 2 {
+		t.Errorf(errorf, "Dependencies size mismatch", 2, len(a.Dependencies()))
+	}
+	if got := a.dependenciesSection().String(); got != expected {
+		t.Errorf(errorf, "Markdown doesn't match", expected, got)
+	}
+}
@@ -0,0 +1,119 @@
+package action
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nu12/action-docs/internal/markdown"
+)
+
+type Runs struct {
+	Using          string            `yaml:"using" json:"using,omitempty"`
+	Main           string            `yaml:"main" json:"main,omitempty"`
+	Pre            string            `yaml:"pre" json:"pre,omitempty"`
+	PreIf          string            `yaml:"pre-if" json:"pre-if,omitempty"`
+	Post           string            `yaml:"post" json:"post,omitempty"`
+	PostIf         string            `yaml:"post-if" json:"post-if,omitempty"`
+	Image          string            `yaml:"image" json:"image,omitempty"`
+	Entrypoint     string            `yaml:"entrypoint" json:"entrypoint,omitempty"`
+	PreEntrypoint  string            `yaml:"pre-entrypoint" json:"pre-entrypoint,omitempty"`
+	PostEntrypoint string            `yaml:"post-entrypoint" json:"post-entrypoint,omitempty"`
+	Args           []string          `yaml:"args" json:"args,omitempty"`
+	Env            map[string]string `yaml:"env" json:"env,omitempty"`
+	Steps          []Step            `yaml:"steps" json:"steps,omitempty"`
+}
+
+type Step struct {
+	ID    string            `yaml:"id" json:"id,omitempty"`
+	Name  string            `yaml:"name" json:"name,omitempty"`
+	If    string            `yaml:"if" json:"if,omitempty"`
+	Uses  string            `yaml:"uses" json:"uses,omitempty"`
+	Run   string            `yaml:"run" json:"run,omitempty"`
+	Shell string            `yaml:"shell" json:"shell,omitempty"`
+	With  map[string]string `yaml:"with" json:"with,omitempty"`
+}
+
+func (r *Runs) Type() string {
+	switch {
+	case r.Using == "composite":
+		return "Composite"
+	case r.Using == "docker":
+		return "Docker"
+	case strings.HasPrefix(r.Using, "node"):
+		return "JavaScript (" + r.Using + ")"
+	}
+	return r.Using
+}
+
+func (r *Runs) IsComposite() bool {
+	return r.Using == "composite"
+}
+
+func (r *Runs) section(newLine string) *markdown.Section {
+	s := markdown.NewSection("runs")
+	if r == nil || r.Using == "" {
+		return s
+	}
+
+	s.Add(markdown.H2("Runs"))
+
+	properties := markdown.Table{
+		Header:   markdown.Header{"Property", "Value"},
+		Verbatim: []int{1},
+		NewLine:  newLine,
+	}
+	properties.AddRow(markdown.Row{"Type", markdown.Escape(r.Type())})
+	for _, p := range []struct{ name, value string }{
+		{"Main", r.Main},
+		{"Pre", r.Pre},
+		{"Pre if", r.PreIf},
+		{"Post", r.Post},
+		{"Post if", r.PostIf},
+		{"Image", r.Image},
+		{"Entrypoint", r.Entrypoint},
+		{"Pre entrypoint", r.PreEntrypoint},
+		{"Post entrypoint", r.PostEntrypoint},
+	} {
+		if p.value != "" {
+			properties.AddRow(markdown.Row{p.name, markdown.InlineCode(p.value)})
+		}
+	}
+	if len(r.Args) > 0 {
+		args := []string{}
+		for _, arg := range r.Args {
+			args = append(args, markdown.InlineCode(arg))
+		}
+		properties.AddRow(markdown.Row{"Args", strings.Join(args, " ")})
+	}
+	s.Add(&properties)
+
+	if len(r.Env) > 0 {
+		s.Add(markdown.H3("Environment"))
+
+		env := markdown.Table{
+			Header:   markdown.Header{"Name", "Value"},
+			Verbatim: []int{1},
+			NewLine:  newLine,
+		}
+		for name, value := range r.Env {
+			env.AddRow(markdown.Row{name, markdown.InlineCode(value)})
+		}
+		s.Add(env.Sort(0))
+	}
+
+	if len(r.Steps) > 0 {
+		s.Add(markdown.H3("Steps"))
+
+		steps := markdown.Table{
+			Header:   markdown.Header{"#", "Name", "Uses", "Shell"},
+			Verbatim: []int{2},
+			NewLine:  newLine,
+		}
+		for i, step := range r.Steps {
+			steps.AddRow(markdown.Row{strconv.Itoa(i + 1), step.Name, markdown.InlineCode(step.Uses), step.Shell})
+		}
+		s.Add(&steps)
+	}
+
+	return s
+}
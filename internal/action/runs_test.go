@@ -0,0 +1,91 @@
+package action
+
+import (
+	"os"
+	"testing"
+)
+
+func TestRuns(t *testing.T) {
+	tests := []struct {
+		name             string
+		data             string
+		expectedType     string
+		expectedMarkdown string
+	}{
+		{
+			name: "Node action",
+			data: `
+name: 'Node action'
+runs:
+  using: 'node20'
+  main: 'dist/index.js'
+  post: 'dist/cleanup.js'
+  post-if: 'success()'
+`,
+			expectedType:     "JavaScript (node20)",
+			expectedMarkdown: "## Runs\n\n|Property|Value|\n|---|---|\n|Type|JavaScript (node20)|\n|Main|`dist/index.js`|\n|Post|`dist/cleanup.js`|\n|Post if|`success()`|\n\n",
+		},
+		{
+			name: "Docker action",
+			data: `
+name: 'Docker action'
+runs:
+  using: 'docker'
+  image: 'Dockerfile'
+  args:
+  - '--verbose'
+  - '${{ inputs.target }}'
+  env:
+    MODE: 'release'
+    DEBUG: 'false'
+`,
+			expectedType:     "Docker",
+			expectedMarkdown: "## Runs\n\n|Property|Value|\n|---|---|\n|Type|Docker|\n|Image|`Dockerfile`|\n|Args|`--verbose` `${{ inputs.target }}`|\n\n### Environment\n\n|Name|Value|\n|---|---|\n|DEBUG|`false`|\n|MODE|`release`|\n\n",
+		},
+		{
+			name: "Composite action",
+			data: `
+name: 'Composite action'
+runs:
+  using: 'composite'
+  steps:
+  - name: 'Checkout'
+    uses: 'actions/checkout@v4'
+  - name: 'Build'
+    run: 'make'
+    shell: 'bash'
+`,
+			expectedType:     "Composite",
+			expectedMarkdown: "## Runs\n\n|Property|Value|\n|---|---|\n|Type|Composite|\n\n### Steps\n\n|#|Name|Uses|Shell|\n|---|---|---|---|\n|1|Checkout|`actions/checkout@v4`||\n|2|Build||bash|\n\n",
+		},
+		{
+			name: "Without runs",
+			data: `
+name: 'Action without runs'
+`,
+			expectedMarkdown: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpFile := t.TempDir() + "/" + filename
+			if err := os.WriteFile(tmpFile, []byte(tt.data), 0644); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+
+			a, diagnostics := Parse(tmpFile)
+			if len(diagnostics) > 0 {
+				t.Fatalf("error: %v", diagnostics)
+			}
+
+			if a.Runs != nil && a.Runs.Type() != tt.expectedType {
+				t.Errorf(errorf, "Type doesn't match", tt.expectedType, a.Runs.Type())
+			}
+
+			if got := a.Runs.section("").String(); got != tt.expectedMarkdown {
+				t.Errorf(errorf, "Markdown doesn't match", tt.expectedMarkdown, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package action
+
+import (
+	_ "embed"
+	"text/template"
+
+	"github.com/nu12/action-docs/internal/render"
+	"github.com/nu12/action-docs/internal/repo"
+	"github.com/nu12/action-docs/internal/types"
+)
+
+//go:embed action.md.tmpl
+var Template string
+
+// Data is the model given to action templates.
+type Data struct {
+	Action       *Action
+	Name         string
+	Description  string
+	Comment      string
+	File         string
+	Path         string
+	Usage        string
+	Inputs       []types.NamedInput
+	Outputs      []types.NamedOutput
+	Runs         *Runs
+	Dependencies []Dependency
+	Repo         repo.Info
+	Sections     map[string]string
+}
+
+func (a *Action) Data(r repo.Info) *Data {
+	sections := map[string]string{}
+	for _, section := range a.Sections() {
+		sections[section.Name] = section.String()
+	}
+
+	return &Data{
+		Action:       a,
+		Name:         a.Name,
+		Description:  a.Description,
+		Comment:      a.Comment,
+		File:         a.Filename,
+		Path:         r.Rel(a.Filename),
+		Usage:        a.Usage(),
+		Inputs:       a.getInputs().List(),
+		Outputs:      a.getOutputs().List(),
+		Runs:         a.Runs,
+		Dependencies: a.Dependencies(),
+		Repo:         r,
+		Sections:     sections,
+	}
+}
+
+// Render executes t with the action data, using the built-in layout when t is nil.
+func (a *Action) Render(t *template.Template, r repo.Info) (string, error) {
+	if t == nil {
+		var err error
+		if t, err = render.New("action", Template); err != nil {
+			return "", err
+		}
+	}
+	return render.Execute(t, a.Data(r))
+}
@@ -0,0 +1,55 @@
+package action
+
+import (
+	"os"
+	"testing"
+
+	"github.com/nu12/action-docs/internal/render"
+	"github.com/nu12/action-docs/internal/repo"
+)
+
+func TestRender(t *testing.T) {
+	data := `
+name: 'Templated action'
+description: 'Description of the templated action'
+inputs:
+  b:
+    description: 'Input b'
+  a:
+    description: 'Input a'
+    required: true
+outputs:
+  out:
+    description: 'Output'
+`
+	tmpl := `{{ h1 .Name }}![badge](https://example.com/{{ .Path }})
+
+{{ range .Inputs }}* {{ .Name }}{{ if .Required }} (required){{ end }}: {{ .Description }}
+{{ end }}{{ .Sections.outputs }}Support: #team`
+	expected := "# Templated action\n\n![badge](https://example.com/actions/a/action.yml)\n\n* a (required): Input a\n* b: Input b\n## Outputs\n\n|Name|Description|\n|---|---|\n|out|Output|\n\nSupport: #team"
+
+	root := t.TempDir()
+	if err := os.MkdirAll(root+"/actions/a", 0755); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	file := root + "/actions/a/" + filename
+	if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	a, diagnostics := Parse(file)
+	if len(diagnostics) > 0 {
+		t.Fatalf("error: %v", diagnostics)
+	}
+	parsed, err := render.New("custom", tmpl)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	got, err := a.Render(parsed, repo.Info{Root: root})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if got != expected {
+		t.Errorf(errorf, "Template doesn't match", expected, got)
+	}
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/viper"
+)
+
+// Names are the accepted config file names, looked up in the home directory
+// and from the working directory upwards.
+var Names = []string{".action-docs.yaml", ".action-docs.yml"}
+
+//go:embed schema.json
+var Schema []byte
+
+// Config mirrors the command line flags. Every key of a config file must map
+// to one of its fields.
+type Config struct {
+	Check        bool      `mapstructure:"check"`
+	Fallback     string    `mapstructure:"fallback"`
+	Format       string    `mapstructure:"format"`
+	JSONOutput   string    `mapstructure:"json-output"`
+	DescribeWith string    `mapstructure:"describe-with"`
+	TableNewline string    `mapstructure:"table-newline"`
+	CodeDefaults int       `mapstructure:"code-defaults"`
+	Stamp        bool      `mapstructure:"stamp"`
+	Repository   string    `mapstructure:"repository"`
+	Ref          string    `mapstructure:"ref"`
+	Actions      Actions   `mapstructure:"actions"`
+	Workflows    Workflows `mapstructure:"workflows"`
+}
+
+type Actions struct {
+	Path      string   `mapstructure:"path"`
+	Manifests []string `mapstructure:"manifests"`
+	Include   []string `mapstructure:"include"`
+	Exclude   []string `mapstructure:"exclude"`
+	Template  string   `mapstructure:"template"`
+}
+
+type Workflows struct {
+	Path          string   `mapstructure:"path"`
+	Output        string   `mapstructure:"output"`
+	Include       []string `mapstructure:"include"`
+	Exclude       []string `mapstructure:"exclude"`
+	Template      string   `mapstructure:"template"`
+	NextRuns      int      `mapstructure:"next-runs"`
+	ReferenceTime string   `mapstructure:"reference-time"`
+}
+
+// Files returns the config files to load, lowest precedence first: the home
+// config followed by the closest config found from dir upwards.
+func Files(dir, home string) []string {
+	var files []string
+	if f := find(home); f != "" {
+		files = append(files, f)
+	}
+
+	for {
+		if f := find(dir); f != "" {
+			if len(files) == 0 || files[0] != f {
+				files = append(files, f)
+			}
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return files
+}
+
+func find(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	for _, name := range Names {
+		f := filepath.Join(dir, name)
+		if _, err := os.Stat(f); err == nil {
+			return f
+		}
+	}
+	return ""
+}
+
+// pathKeys are resolved relative to the directory of the config file.
+var pathKeys = []string{"json-output", "actions.path", "actions.template", "workflows.path", "workflows.output", "workflows.template"}
+
+// Read loads file, failing when it contains unknown keys. Relative paths are
+// rewritten from the config file directory to be relative to wd instead.
+func Read(file, wd string) (map[string]any, error) {
+	if _, err := os.Stat(file); errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("config file %s not found", file)
+	}
+
+	v := viper.New()
+	v.SetConfigFile(file)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if err := v.UnmarshalExact(&Config{}); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	for _, key := range pathKeys {
+		path := v.GetString(key)
+		if path == "" || filepath.IsAbs(path) {
+			continue
+		}
+		path = filepath.Join(filepath.Dir(file), path)
+		if rel, err := filepath.Rel(wd, path); err == nil {
+			path = rel
+		}
+		v.Set(key, path)
+	}
+	return v.AllSettings(), nil
+}
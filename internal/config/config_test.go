@@ -0,0 +1,166 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const errorf = "Error: %v. \nExpected: %v \nGot: %v"
+
+func TestFiles(t *testing.T) {
+	home := t.TempDir()
+	repo := t.TempDir()
+	if err := os.MkdirAll(repo+"/a/b", 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(home+"/.action-docs.yaml", []byte(""), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(repo+"/.action-docs.yml", []byte(""), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		dir      string
+		home     string
+		expected []string
+	}{
+		{
+			name:     "Home and repository",
+			dir:      repo + "/a/b",
+			home:     home,
+			expected: []string{home + "/.action-docs.yaml", repo + "/.action-docs.yml"},
+		},
+		{
+			name:     "Repository only",
+			dir:      repo,
+			home:     "",
+			expected: []string{repo + "/.action-docs.yml"},
+		},
+		{
+			name:     "Working directory is home",
+			dir:      home,
+			home:     home,
+			expected: []string{home + "/.action-docs.yaml"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Files(tt.dir, tt.home); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf(errorf, "Files don't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		isValid bool
+	}{
+		{
+			name:    "Known keys",
+			data:    "check: true\nactions:\n  path: actions\n  exclude: ['internal/**']\nworkflows:\n  output: docs\n",
+			isValid: true,
+		},
+		{
+			name:    "Unknown top-level key",
+			data:    "checks: true\n",
+			isValid: false,
+		},
+		{
+			name:    "Unknown nested key",
+			data:    "workflows:\n  paths: ci\n",
+			isValid: false,
+		},
+		{
+			name:    "Invalid yaml",
+			data:    "actions: [\n",
+			isValid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := t.TempDir() + "/.action-docs.yaml"
+			if err := os.WriteFile(file, []byte(tt.data), 0644); err != nil {
+				t.Fatalf("failed to write file: %v", err)
+			}
+			if _, err := Read(file, "."); (err == nil) != tt.isValid {
+				t.Errorf(errorf, "Validation doesn't match", tt.isValid, err)
+			}
+		})
+	}
+}
+
+func TestReadPaths(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(repo+"/sub", 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	file := repo + "/.action-docs.yaml"
+	if err := os.WriteFile(file, []byte("actions:\n  path: actions\nworkflows:\n  path: /ci/workflows\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	settings, err := Read(file, repo+"/sub")
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	actions := settings["actions"].(map[string]any)
+	if actions["path"] != "../actions" {
+		t.Errorf(errorf, "Relative path doesn't match", "../actions", actions["path"])
+	}
+	workflows := settings["workflows"].(map[string]any)
+	if workflows["path"] != "/ci/workflows" {
+		t.Errorf(errorf, "Absolute path doesn't match", "/ci/workflows", workflows["path"])
+	}
+}
+
+func TestSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(Schema, &schema); err != nil {
+		t.Fatalf("invalid schema: %v", err)
+	}
+
+	expected := keys(reflect.TypeOf(Config{}), "")
+	got := properties(schema, "")
+	sort.Strings(expected)
+	sort.Strings(got)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf(errorf, "Schema doesn't match the config", expected, got)
+	}
+}
+
+func keys(t reflect.Type, prefix string) []string {
+	var result []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		key := prefix + f.Tag.Get("mapstructure")
+		if f.Type.Kind() == reflect.Struct {
+			result = append(result, keys(f.Type, key+".")...)
+			continue
+		}
+		result = append(result, key)
+	}
+	return result
+}
+
+func properties(schema map[string]any, prefix string) []string {
+	var result []string
+	for name, p := range schema["properties"].(map[string]any) {
+		property := p.(map[string]any)
+		if property["type"] == "object" {
+			result = append(result, properties(property, prefix+name+".")...)
+			continue
+		}
+		result = append(result, prefix+name)
+	}
+	return result
+}
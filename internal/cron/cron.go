@@ -0,0 +1,315 @@
+package cron
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed POSIX cron expression, as accepted by the schedule
+// trigger of GitHub Actions. Schedules always run in UTC.
+type Schedule struct {
+	Expression string
+	Minute     Field
+	Hour       Field
+	Day        Field
+	Month      Field
+	Weekday    Field
+}
+
+// Field is the set of values matched by one field of an expression.
+type Field struct {
+	// Values are sorted and unique.
+	Values []int
+	// Any is set for `*` fields.
+	Any bool
+	// Wildcard is set for fields starting with `*`, such as `*/2`, which cron
+	// doesn't count as restricted when combining the two day fields.
+	Wildcard bool
+	// Step is the interval of `*/n` fields, 0 otherwise.
+	Step int
+}
+
+type bounds struct {
+	min, max int
+	names    []string
+}
+
+var (
+	minutes  = bounds{min: 0, max: 59}
+	hours    = bounds{min: 0, max: 23}
+	days     = bounds{min: 1, max: 31}
+	months   = bounds{min: 1, max: 12, names: []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	weekdays = bounds{min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+// Parse parses an expression of five fields: minute, hour, day of the month,
+// month and day of the week.
+func Parse(expression string) (*Schedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expression, len(fields))
+	}
+
+	s := &Schedule{Expression: expression}
+	for i, f := range []struct {
+		field  *Field
+		bounds bounds
+		name   string
+	}{
+		{&s.Minute, minutes, "minute"},
+		{&s.Hour, hours, "hour"},
+		{&s.Day, days, "day of the month"},
+		{&s.Month, months, "month"},
+		{&s.Weekday, weekdays, "day of the week"},
+	} {
+		field, err := parseField(fields[i], f.bounds)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %s: %w", expression, f.name, err)
+		}
+		*f.field = field
+	}
+
+	// Sunday is both 0 and 7.
+	if n := len(s.Weekday.Values); n > 0 && s.Weekday.Values[n-1] == 7 {
+		s.Weekday.Values = unique(append([]int{0}, s.Weekday.Values[:n-1]...))
+	}
+	return s, nil
+}
+
+func parseField(text string, b bounds) (Field, error) {
+	field := Field{Any: text == "*", Wildcard: strings.HasPrefix(text, "*")}
+	var values []int
+	for _, part := range strings.Split(text, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return field, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+			rng, step = part[:i], n
+			if rng == "*" && !strings.Contains(text, ",") {
+				field.Step = n
+			}
+		}
+
+		first, last := b.min, b.max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if first, err = b.value(bounds[0]); err != nil {
+				return field, err
+			}
+			last = first
+			if len(bounds) == 2 {
+				if last, err = b.value(bounds[1]); err != nil {
+					return field, err
+				}
+			} else if step > 1 {
+				last = b.max
+			}
+			if first > last {
+				return field, fmt.Errorf("invalid range %q", rng)
+			}
+		}
+		for v := first; v <= last; v += step {
+			values = append(values, v)
+		}
+	}
+	field.Values = unique(values)
+	return field, nil
+}
+
+func (b bounds) value(text string) (int, error) {
+	for i, name := range b.names {
+		if name != "" && strings.EqualFold(text, name) {
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", text)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, b.min, b.max)
+	}
+	return v, nil
+}
+
+func unique(values []int) []int {
+	sort.Ints(values)
+	result := values[:0]
+	for i, v := range values {
+		if i == 0 || v != values[i-1] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// String describes when the schedule runs, e.g. "every Monday at 03:00 UTC".
+func (s *Schedule) String() string {
+	when, at := s.times()
+	on := s.days()
+	switch {
+	case at:
+		return on + " " + when
+	case on == "every day":
+		return when
+	}
+	return when + ", " + on
+}
+
+// times describes the minutes and hours of the schedule. at is set when the
+// description is a list of times rather than a frequency.
+func (s *Schedule) times() (description string, at bool) {
+	m, h := s.Minute, s.Hour
+	switch {
+	case h.Any && m.Any:
+		return "every minute", false
+	case h.Any && m.Step > 0:
+		return fmt.Sprintf("every %d minutes", m.Step), false
+	case h.Any && len(m.Values) == 1 && m.Values[0] == 0:
+		return "every hour", false
+	case h.Any:
+		return "every hour at minute " + describe(m.Values, strconv.Itoa), false
+	case m.Any || m.Step > 0:
+		every := "every minute"
+		if m.Step > 0 {
+			every = fmt.Sprintf("every %d minutes", m.Step)
+		}
+		return every + " during hour " + describe(h.Values, strconv.Itoa) + " UTC", false
+	case h.Step > 0 && len(m.Values) == 1:
+		every := fmt.Sprintf("every %d hours", h.Step)
+		if m.Values[0] != 0 {
+			every += fmt.Sprintf(" at minute %d", m.Values[0])
+		}
+		return every, false
+	case len(m.Values)*len(h.Values) <= 6:
+		var times []string
+		for _, hour := range h.Values {
+			for _, minute := range m.Values {
+				times = append(times, fmt.Sprintf("%02d:%02d", hour, minute))
+			}
+		}
+		return "at " + list(times) + " UTC", true
+	}
+	return "at minute " + describe(m.Values, strconv.Itoa) + " of hour " + describe(h.Values, strconv.Itoa) + " UTC", true
+}
+
+// days describes the days of the schedule.
+func (s *Schedule) days() string {
+	d, mo, w := s.Day, s.Month, s.Weekday
+
+	var description string
+	switch {
+	case d.Any && w.Any:
+		description = "every day"
+	case d.Step > 1 && w.Any:
+		description = fmt.Sprintf("every %d days", d.Step)
+	case d.Any:
+		description = "every " + describe(w.Values, weekday)
+	case w.Any:
+		description = "on day " + describe(d.Values, strconv.Itoa)
+	case d.Wildcard || w.Wildcard:
+		// Days must match both fields.
+		description = "on day " + describe(d.Values, strconv.Itoa)
+		if d.Step > 1 {
+			description = fmt.Sprintf("every %d days", d.Step)
+		}
+		description += ", only on " + describe(w.Values, weekday)
+	default:
+		description = "on day " + describe(d.Values, strconv.Itoa) + " and every " + describe(w.Values, weekday)
+	}
+
+	switch {
+	case !mo.Any && strings.HasPrefix(description, "on day"):
+		description += " of " + describe(mo.Values, month)
+	case !mo.Any:
+		description += " in " + describe(mo.Values, month)
+	case strings.HasPrefix(description, "on day"):
+		description += " of every month"
+	}
+	return description
+}
+
+func weekday(v int) string {
+	return time.Weekday(v).String()
+}
+
+func month(v int) string {
+	return time.Month(v).String()
+}
+
+// describe lists values, as a range when there are three or more
+// consecutive ones.
+func describe(values []int, name func(int) string) string {
+	if len(values) >= 3 && values[len(values)-1]-values[0] == len(values)-1 {
+		return name(values[0]) + " to " + name(values[len(values)-1])
+	}
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = name(v)
+	}
+	return list(names)
+}
+
+func list(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
+
+// Next returns the first time after t matching the schedule, in UTC. The
+// zero time is returned when no time matches within five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !s.Month.matches(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !s.Hour.matches(t.Hour()):
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case !s.Minute.matches(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// NextRuns returns up to n times after t matching the schedule.
+func (s *Schedule) NextRuns(t time.Time, n int) []time.Time {
+	var runs []time.Time
+	for len(runs) < n {
+		if t = s.Next(t); t.IsZero() {
+			break
+		}
+		runs = append(runs, t)
+	}
+	return runs
+}
+
+// matchesDay follows cron: when both the day of the month and the day of the
+// week are restricted, matching either one is enough. Fields starting with
+// `*` aren't restricted for this rule.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	day, weekday := s.Day.matches(t.Day()), s.Weekday.matches(int(t.Weekday()))
+	if !s.Day.Wildcard && !s.Weekday.Wildcard {
+		return day || weekday
+	}
+	return day && weekday
+}
+
+func (f Field) matches(v int) bool {
+	_, found := slices.BinarySearch(f.Values, v)
+	return found
+}
@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+const errorf = "Error: %v. \nExpected: %v \nGot: %v"
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+	}{
+		{"0 3 * * 1", "every Monday at 03:00 UTC"},
+		{"0 3 * * *", "every day at 03:00 UTC"},
+		{"30 2 1 * *", "on day 1 of every month at 02:30 UTC"},
+		{"0 0 1,15 JAN *", "on day 1 and 15 of January at 00:00 UTC"},
+		{"0 9 * * MON-FRI", "every Monday to Friday at 09:00 UTC"},
+		{"0 9,17 * * 1-5", "every Monday to Friday at 09:00 and 17:00 UTC"},
+		{"0 12 * * 0,7", "every Sunday at 12:00 UTC"},
+		{"0 0 * 6-8 *", "every day in June to August at 00:00 UTC"},
+		{"* * * * *", "every minute"},
+		{"*/15 * * * *", "every 15 minutes"},
+		{"0 * * * *", "every hour"},
+		{"5,35 * * * 1", "every hour at minute 5 and 35, every Monday"},
+		{"0 */6 * * *", "every 6 hours"},
+		{"*/10 9-17 * * 1-5", "every 10 minutes during hour 9 to 17 UTC, every Monday to Friday"},
+		{"0 0 */2 * *", "every 2 days at 00:00 UTC"},
+		{"0 0 1 * 1", "on day 1 and every Monday of every month at 00:00 UTC"},
+		{"0 0 */2 * 1", "every 2 days, only on Monday at 00:00 UTC"},
+		{"0,10,20,30 1,2 * * *", "every day at minute 0, 10, 20 and 30 of hour 1 and 2 UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			s, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if got := s.String(); got != tt.expected {
+				t.Errorf(errorf, "Description doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"0 3 * *",
+		"60 * * * *",
+		"0 24 * * *",
+		"0 0 0 * *",
+		"0 0 * 13 *",
+		"0 0 * * 8",
+		"0 0 * * FOO",
+		"*/0 * * * *",
+		"5-1 * * * *",
+	}
+
+	for _, expression := range tests {
+		t.Run(expression, func(t *testing.T) {
+			if _, err := Parse(expression); err == nil {
+				t.Errorf(errorf, "Invalid expression should fail", "error", nil)
+			}
+		})
+	}
+}
+
+func TestNextRuns(t *testing.T) {
+	from := time.Date(2024, time.February, 27, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		expression string
+		expected   []string
+	}{
+		{"0 3 * * 1", []string{"2024-03-04 03:00", "2024-03-11 03:00", "2024-03-18 03:00"}},
+		{"*/20 * * * *", []string{"2024-02-27 10:40", "2024-02-27 11:00", "2024-02-27 11:20"}},
+		{"30 10 * * *", []string{"2024-02-28 10:30", "2024-02-29 10:30", "2024-03-01 10:30"}},
+		{"0 0 29 2 *", []string{"2024-02-29 00:00", "2028-02-29 00:00", "2032-02-29 00:00"}},
+		{"0 0 1 * 5", []string{"2024-03-01 00:00", "2024-03-08 00:00", "2024-03-15 00:00"}},
+		{"0 0 30 2 *", nil},
+		{"0 0 */2 * 1", []string{"2024-03-11 00:00", "2024-03-25 00:00", "2024-04-01 00:00"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			s, err := Parse(tt.expression)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			var got []string
+			for _, run := range s.NextRuns(from, 3) {
+				got = append(got, run.Format("2006-01-02 15:04"))
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf(errorf, "Next runs don't match", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package diagnostic
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	Error   = "error"
+	Warning = "warning"
+)
+
+type Diagnostic struct {
+	File     string
+	Line     int
+	Column   int
+	Severity string
+	Message  string
+	// Rule is the ID of the lint rule that reported the diagnostic, if any.
+	Rule string
+}
+
+// String formats the diagnostic as "file:line:col: severity: message",
+// omitting the line and column when unknown.
+func (d Diagnostic) String() string {
+	s := d.File
+	if d.Line > 0 {
+		s += ":" + strconv.Itoa(d.Line)
+		if d.Column > 0 {
+			s += ":" + strconv.Itoa(d.Column)
+		}
+	}
+	s += fmt.Sprintf(": %s: %s", d.Severity, d.Message)
+	if d.Rule != "" {
+		s += " [" + d.Rule + "]"
+	}
+	return s
+}
+
+type List []Diagnostic
+
+func (l List) HasErrors() bool {
+	for _, d := range l {
+		if d.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+var yamlLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// FromError converts an error returned while reading or decoding file,
+// extracting the line of YAML errors.
+func FromError(file string, err error) List {
+	var messages []string
+	var typeError *yaml.TypeError
+	if errors.As(err, &typeError) {
+		messages = typeError.Errors
+	} else {
+		messages = []string{err.Error()}
+	}
+
+	result := List{}
+	for _, message := range messages {
+		d := Diagnostic{File: file, Severity: Error, Message: message}
+		if m := yamlLine.FindStringSubmatch(message); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+			d.Message = m[2]
+		}
+		result = append(result, d)
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package diagnostic
+
+import (
+	"errors"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const errorf = "Error: %v. \nExpected: %v \nGot: %v"
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		name       string
+		diagnostic Diagnostic
+		expected   string
+	}{
+		{
+			name:       "Line and column",
+			diagnostic: Diagnostic{File: "action.yml", Line: 3, Column: 5, Severity: Error, Message: "broken"},
+			expected:   "action.yml:3:5: error: broken",
+		},
+		{
+			name:       "Line only",
+			diagnostic: Diagnostic{File: "action.yml", Line: 3, Severity: Warning, Message: "broken"},
+			expected:   "action.yml:3: warning: broken",
+		},
+		{
+			name:       "No position",
+			diagnostic: Diagnostic{File: "action.yml", Severity: Error, Message: "broken"},
+			expected:   "action.yml: error: broken",
+		},
+		{
+			name:       "Rule",
+			diagnostic: Diagnostic{File: "action.yml", Line: 1, Column: 1, Severity: Error, Message: "broken", Rule: "some-rule"},
+			expected:   "action.yml:1:1: error: broken [some-rule]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.diagnostic.String(); got != tt.expected {
+				t.Errorf(errorf, "String doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestFromError(t *testing.T) {
+	var node yaml.Node
+	syntaxErr := yaml.Unmarshal([]byte("a: b\nc: [\n"), &node)
+	var target struct {
+		A string `yaml:"a"`
+		B int    `yaml:"b"`
+	}
+	typeErr := yaml.Unmarshal([]byte("a: [1]\nb: x\n"), &target)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{
+			name:     "Syntax error",
+			err:      syntaxErr,
+			expected: []string{"f.yml:2: error: did not find expected node content"},
+		},
+		{
+			name: "Type errors",
+			err:  typeErr,
+			expected: []string{
+				"f.yml:1: error: cannot unmarshal !!seq into string",
+				"f.yml:2: error: cannot unmarshal !!str `x` into int",
+			},
+		},
+		{
+			name:     "Other error",
+			err:      errors.New("open f.yml: no such file or directory"),
+			expected: []string{"f.yml: error: open f.yml: no such file or directory"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromError("f.yml", tt.err)
+			if len(got) != len(tt.expected) {
+				t.Fatalf(errorf, "Diagnostics size mismatch", tt.expected, got)
+			}
+			for i := range got {
+				if got[i].String() != tt.expected[i] {
+					t.Errorf(errorf, "Diagnostic doesn't match", tt.expected[i], got[i].String())
+				}
+			}
+			if !got.HasErrors() {
+				t.Errorf("error: %s", "Diagnostics should have errors")
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+const context = 3
+
+type op struct {
+	kind byte
+	line string
+}
+
+// Unified returns the differences between a and b in unified diff format, or
+// an empty string when both are equal.
+func Unified(a, b, fromFile, toFile string) string {
+	if a == b {
+		return ""
+	}
+	ops := compare(lines(a), lines(b))
+
+	out := fmt.Sprintf("--- %s\n+++ %s\n", fromFile, toFile)
+	for _, h := range hunks(ops) {
+		out += h
+	}
+	return out
+}
+
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	l := strings.SplitAfter(s, "\n")
+	if l[len(l)-1] == "" {
+		l = l[:len(l)-1]
+	}
+	return l
+}
+
+// compare computes the edit script between a and b from their longest common
+// subsequence.
+func compare(a, b []string) []op {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []op
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, op{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, op{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, op{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, op{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{'+', b[j]})
+	}
+	return ops
+}
+
+func hunks(ops []op) []string {
+	var result []string
+	lineA, lineB := 1, 1
+	for start := 0; start < len(ops); {
+		if ops[start].kind == ' ' {
+			start++
+			lineA++
+			lineB++
+			continue
+		}
+
+		// Extend the hunk until the next change is further than two contexts away.
+		end := start
+		for k := start; k < len(ops); k++ {
+			if ops[k].kind != ' ' {
+				end = k + 1
+			} else if k-end >= 2*context {
+				break
+			}
+		}
+
+		from := max(start-context, 0)
+		to := min(end+context, len(ops))
+		startA, startB := lineA-(start-from), lineB-(start-from)
+		countA, countB := 0, 0
+		body := ""
+		for _, o := range ops[from:to] {
+			if o.kind != '+' {
+				countA++
+			}
+			if o.kind != '-' {
+				countB++
+			}
+			body += string(o.kind) + o.line
+			if !strings.HasSuffix(o.line, "\n") {
+				body += "\n\\ No newline at end of file\n"
+			}
+		}
+		result = append(result, fmt.Sprintf("@@ -%s +%s @@\n%s", span(startA, countA), span(startB, countB), body))
+
+		for _, o := range ops[start:to] {
+			if o.kind != '+' {
+				lineA++
+			}
+			if o.kind != '-' {
+				lineB++
+			}
+		}
+		start = to
+	}
+	return result
+}
+
+func span(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
@@ -0,0 +1,87 @@
+package export
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/nu12/action-docs/internal/action"
+	"github.com/nu12/action-docs/internal/helper"
+	"github.com/nu12/action-docs/internal/workflow"
+)
+
+// SchemaVersion is bumped on every incompatible change of the document.
+const SchemaVersion = 1
+
+const (
+	Markdown = "markdown"
+	JSON     = "json"
+)
+
+// Document is the JSON representation of the parsed actions and workflows.
+type Document struct {
+	SchemaVersion int                  `json:"schemaVersion"`
+	Actions       []*action.Action     `json:"actions,omitempty"`
+	Workflows     []*workflow.Workflow `json:"workflows,omitempty"`
+}
+
+func New() *Document {
+	return &Document{SchemaVersion: SchemaVersion}
+}
+
+func (d *Document) AddAction(a *action.Action) *Document {
+	d.Actions = append(d.Actions, a)
+	return d
+}
+
+func (d *Document) AddWorkflow(w *workflow.Workflow) *Document {
+	d.Workflows = append(d.Workflows, w)
+	return d
+}
+
+func (d *Document) JSON() (string, error) {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+// Hash returns the hash of the JSON document. It changes with what is
+// documented, not with the formatting of the YAML it was parsed from nor with
+// the path the files were found at, as file names are made relative to dir.
+func (d *Document) Hash(dir string) (string, error) {
+	rel := New()
+	for _, a := range d.Actions {
+		a := *a
+		a.Filename = relative(dir, a.Filename)
+		rel.AddAction(&a)
+	}
+	for _, w := range d.Workflows {
+		w := *w
+		w.Filename = relative(dir, w.Filename)
+		rel.AddWorkflow(&w)
+	}
+	b, err := json.Marshal(rel)
+	if err != nil {
+		return "", err
+	}
+	return helper.Hash(string(b)), nil
+}
+
+// relative returns file relative to dir, using forward slashes. Files that
+// can't be made relative are returned unchanged.
+func relative(dir, file string) string {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	rel, err := filepath.Rel(absDir, absFile)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,219 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nu12/action-docs/internal/action"
+	"github.com/nu12/action-docs/internal/workflow"
+)
+
+const errorf = "Error: %v. \nExpected: %v \nGot: %v"
+
+func TestDocument(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/action.yml", []byte(`
+name: 'Action'
+description: 'Action description'
+inputs:
+  in1:
+    description: 'Input1'
+    required: true
+    default: 'one'
+outputs:
+  out1:
+    description: 'Output1'
+runs:
+  using: 'node20'
+  main: 'index.js'
+`), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if err := os.WriteFile(dir+"/call.yml", []byte(`
+name: 'Workflow'
+on:
+  workflow_call:
+    secrets:
+      sec1:
+        required: true
+`), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	a, diagnostics := action.Parse(dir + "/action.yml")
+	if len(diagnostics) > 0 {
+		t.Fatalf("error: %v", diagnostics)
+	}
+	a.Filename = "action.yml"
+	w, diagnostics := workflow.Parse(dir + "/call.yml")
+	if len(diagnostics) > 0 {
+		t.Fatalf("error: %v", diagnostics)
+	}
+	w.Filename = "call.yml"
+
+	tests := []struct {
+		name     string
+		document *Document
+		expected string
+	}{
+		{
+			name:     "Actions",
+			document: New().AddAction(a),
+			expected: `{
+  "schemaVersion": 1,
+  "actions": [
+    {
+      "name": "Action",
+      "description": "Action description",
+      "inputs": {
+        "in1": {
+          "default": {
+            "value": "one",
+            "kind": "string"
+          },
+          "description": "Input1",
+          "required": true
+        }
+      },
+      "outputs": {
+        "out1": {
+          "description": "Output1"
+        }
+      },
+      "runs": {
+        "using": "node20",
+        "main": "index.js"
+      },
+      "file": "action.yml"
+    }
+  ]
+}
+`,
+		},
+		{
+			name:     "Workflows",
+			document: New().AddWorkflow(w),
+			expected: `{
+  "schemaVersion": 1,
+  "workflows": [
+    {
+      "name": "Workflow",
+      "description": "",
+      "on": {
+        "events": [
+          "workflow_call"
+        ],
+        "workflow_call": {
+          "inputs": {},
+          "outputs": {},
+          "secrets": {
+            "sec1": {
+              "required": true
+            }
+          }
+        }
+      },
+      "file": "call.yml",
+      "isReusableWorkflow": true
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.document.JSON()
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf(errorf, "JSON doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name  string
+		given string
+		same  bool
+	}{
+		{
+			name:  "Formatting",
+			given: "name:   Action\ndescription: \"Action description\"\n\ninputs:\n    in1:\n        description: Input1\n",
+			same:  true,
+		},
+		{
+			name:  "Key order",
+			given: "description: Action description\nname: Action\ninputs:\n  in1: {description: Input1}\n",
+			same:  true,
+		},
+		{
+			name:  "Description",
+			given: "name: Action\ndescription: Action description\ninputs:\n  in1:\n    description: Input 1\n",
+			same:  false,
+		},
+	}
+
+	hash := func(data string) string {
+		file := t.TempDir() + "/action.yml"
+		if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		a, diagnostics := action.Parse(file)
+		if len(diagnostics) > 0 {
+			t.Fatalf("error: %v", diagnostics)
+		}
+		h, err := New().AddAction(a).Hash(filepath.Dir(file))
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		return h
+	}
+	expected := hash("name: Action\ndescription: Action description\ninputs:\n  in1:\n    description: Input1\n")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hash(tt.given); (got == expected) != tt.same {
+				t.Errorf(errorf, "Hash comparison doesn't match", tt.same, got == expected)
+			}
+		})
+	}
+}
+
+func TestHashPaths(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "action.yml"), []byte("name: Action\ndescription: Action description\n"), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	rel, err := filepath.Rel(wd, dir)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	hash := func(file string) string {
+		a, diagnostics := action.Parse(file)
+		if len(diagnostics) > 0 {
+			t.Fatalf("error: %v", diagnostics)
+		}
+		h, err := New().AddAction(a).Hash(filepath.Dir(file))
+		if err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		return h
+	}
+
+	absolute := hash(filepath.Join(dir, "action.yml"))
+	relative := hash(filepath.Join(rel, "action.yml"))
+	if absolute != relative {
+		t.Errorf(errorf, "Hash of the same action found at another path doesn't match", absolute, relative)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/fs"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
@@ -14,17 +15,31 @@ func Hash(data string) string {
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
-func ScanPattern(path, pattern string, recursive bool) ([]string, error) {
+// ScanPattern returns the files under path whose base name matches any of the
+// glob patterns. Subdirectories are only scanned when recursive is set.
+func ScanPattern(path string, patterns []string, recursive bool) ([]string, error) {
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+
 	var files []string
-	err := filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+	err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 		if d.IsDir() {
+			if !recursive && file != path {
+				return filepath.SkipDir
+			}
 			return nil
 		}
-		if strings.Contains(path, pattern) {
-			files = append(files, path)
+		for _, pattern := range patterns {
+			if ok, _ := filepath.Match(pattern, d.Name()); ok {
+				files = append(files, file)
+				break
+			}
 		}
 		return nil
 	})
@@ -34,10 +49,92 @@ func ScanPattern(path, pattern string, recursive bool) ([]string, error) {
 	return files, nil
 }
 
-func SanitizeURL(url string) string {
-	out := strings.Replace(url, " ", "-", -1)
-	out = strings.Replace(out, "(", "", -1)
-	out = strings.Replace(out, ")", "", -1)
-	out = strings.ToLower(out)
-	return out
+// Filter keeps the files matching any include glob (all files when there are
+// none) and none of the exclude globs. Globs are matched against the path
+// relative to root and against the base name, and "**" matches any number of
+// directories.
+func Filter(files []string, root string, include, exclude []string) ([]string, error) {
+	includes, err := compileGlobs(include)
+	if err != nil {
+		return nil, err
+	}
+	excludes, err := compileGlobs(exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	for _, file := range files {
+		rel, err := filepath.Rel(root, file)
+		if err != nil {
+			rel = file
+		}
+		candidates := []string{filepath.ToSlash(rel), filepath.Base(file)}
+		if len(includes) > 0 && !matchAny(includes, candidates) {
+			continue
+		}
+		if matchAny(excludes, candidates) {
+			continue
+		}
+		result = append(result, file)
+	}
+	return result, nil
+}
+
+func compileGlobs(globs []string) ([]*regexp.Regexp, error) {
+	var result []*regexp.Regexp
+	for _, glob := range globs {
+		re, err := globToRegexp(glob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", glob, err)
+		}
+		result = append(result, re)
+	}
+	return result, nil
+}
+
+func globToRegexp(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			j := strings.IndexByte(glob[i:], ']')
+			if j < 0 {
+				return nil, fmt.Errorf("missing ']'")
+			}
+			class := glob[i+1 : i+j]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += j
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+func matchAny(res []*regexp.Regexp, candidates []string) bool {
+	for _, re := range res {
+		for _, c := range candidates {
+			if re.MatchString(c) {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -26,7 +26,7 @@ func TestScanPatternWorkflow(t *testing.T) {
 		t.Fatalf("failed to write file: %v", err)
 	}
 
-	files, err := ScanPattern(dir, ".yml", false)
+	files, err := ScanPattern(dir, []string{"*.yml"}, false)
 	if err != nil {
 		t.Errorf("error: %v", err)
 	}
@@ -70,7 +70,7 @@ func TestScanPatternAction(t *testing.T) {
 		t.Fatalf("failed to write file: %v", err)
 	}
 
-	files, err := ScanPattern(dir, "action.yml", true)
+	files, err := ScanPattern(dir, []string{"action.yml"}, true)
 	if err != nil {
 		t.Errorf("error: %v", err)
 	}
@@ -92,33 +92,121 @@ func TestScanPatternAction(t *testing.T) {
 	}
 }
 
-func TestSanitizeURL(t *testing.T) {
+func TestScanPatternBaseName(t *testing.T) {
+	dir := t.TempDir()
+	for _, d := range []string{"a", "b", "c"} {
+		if err := os.Mkdir(dir+"/"+d, 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+	}
+	for _, f := range []string{"a/action.yml", "b/action.yaml", "c/old-action.yml.bak", "c/action.yml.orig"} {
+		if err := os.WriteFile(dir+"/"+f, []byte(""), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	files, err := ScanPattern(dir, []string{"action.yml", "action.yaml"}, true)
+	if err != nil {
+		t.Errorf("error: %v", err)
+	}
+
+	expected := []string{dir + "/a/action.yml", dir + "/b/action.yaml"}
+	if len(files) != len(expected) {
+		t.Fatalf(errorf, "files size mismatch", len(expected), len(files))
+	}
+	for i := range expected {
+		if files[i] != expected[i] {
+			t.Errorf(errorf, "File doesn't match", expected[i], files[i])
+		}
+	}
+}
+
+func TestScanPatternInvalid(t *testing.T) {
+	if _, err := ScanPattern(t.TempDir(), []string{"["}, true); err == nil {
+		t.Errorf("error: %s", "Invalid pattern should fail")
+	}
+}
+
+func TestScanPatternNotRecursive(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(dir+"/sub", 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	for _, f := range []string{"a.yml", "b.yaml", "sub/c.yml"} {
+		if err := os.WriteFile(dir+"/"+f, []byte(""), 0644); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+	}
+
+	files, err := ScanPattern(dir, []string{"*.yml", "*.yaml"}, false)
+	if err != nil {
+		t.Errorf("error: %v", err)
+	}
+
+	expected := []string{dir + "/a.yml", dir + "/b.yaml"}
+	if len(files) != len(expected) {
+		t.Fatalf(errorf, "files size mismatch", len(expected), len(files))
+	}
+	for i := range expected {
+		if files[i] != expected[i] {
+			t.Errorf(errorf, "File doesn't match", expected[i], files[i])
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	files := []string{
+		"root/build/action.yml",
+		"root/deploy/action.yml",
+		"root/internal/tools/action.yml",
+		"root/experimental.yml",
+	}
 	tests := []struct {
 		name     string
-		given    string
-		expected string
+		include  []string
+		exclude  []string
+		expected []string
 	}{
 		{
-			name:     "Remove spaces",
-			given:    "my workflow",
-			expected: "my-workflow",
+			name:     "No filters",
+			expected: files,
+		},
+		{
+			name:     "Include relative path",
+			include:  []string{"build/*"},
+			expected: []string{"root/build/action.yml"},
 		},
 		{
-			name:     "Remove parenthesis",
-			given:    "my (workflow)",
-			expected: "my-workflow",
+			name:     "Exclude with double star",
+			exclude:  []string{"internal/**"},
+			expected: []string{"root/build/action.yml", "root/deploy/action.yml", "root/experimental.yml"},
 		},
 		{
-			name:     "To lowercase",
-			given:    "My Workflow",
-			expected: "my-workflow",
+			name:     "Exclude base name",
+			exclude:  []string{"experimental*"},
+			expected: []string{"root/build/action.yml", "root/deploy/action.yml", "root/internal/tools/action.yml"},
+		},
+		{
+			name:     "Include and exclude",
+			include:  []string{"**/action.yml"},
+			exclude:  []string{"deploy/*", "**/tools/*"},
+			expected: []string{"root/build/action.yml"},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := SanitizeURL(tt.given); got != tt.expected {
-				t.Errorf(errorf, "mismatch", tt.expected, got)
+			got, err := Filter(files, "root", tt.include, tt.exclude)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf(errorf, "files size mismatch", tt.expected, got)
+			}
+			for i := range tt.expected {
+				if got[i] != tt.expected[i] {
+					t.Errorf(errorf, "File doesn't match", tt.expected[i], got[i])
+				}
 			}
 		})
 	}
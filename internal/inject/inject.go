@@ -0,0 +1,194 @@
+package inject
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nu12/action-docs/internal/markdown"
+)
+
+const (
+	Overwrite = "overwrite"
+	Append    = "append"
+)
+
+func Start(name string) string {
+	return fmt.Sprintf("<!-- action-docs:%s:start -->", name)
+}
+
+func End(name string) string {
+	return fmt.Sprintf("<!-- action-docs:%s:end -->", name)
+}
+
+func Wrap(section *markdown.Section) string {
+	return Start(section.Name) + "\n" + section.String() + End(section.Name) + "\n"
+}
+
+// Replace swaps the content between the markers of every section found in doc.
+// It reports whether at least one marker pair was present.
+func Replace(doc string, sections []*markdown.Section) (string, bool, error) {
+	found := false
+	for _, section := range sections {
+		start, end := Start(section.Name), End(section.Name)
+		i := strings.Index(doc, start)
+		if i < 0 {
+			if strings.Contains(doc, end) {
+				return "", false, fmt.Errorf("marker %q has no matching %q", end, start)
+			}
+			continue
+		}
+		j := strings.Index(doc[i:], end)
+		if j < 0 {
+			return "", false, fmt.Errorf("marker %q has no matching %q", start, end)
+		}
+		j += i
+		doc = doc[:i] + start + "\n" + section.String() + doc[j:]
+		found = true
+	}
+	return doc, found, nil
+}
+
+// Render merges the generated sections into the existing document. When the
+// document has no markers, fallback decides whether the whole file is replaced
+// or the sections are appended wrapped in markers.
+func Render(doc string, sections []*markdown.Section, fallback string) (string, error) {
+	out, found, err := Replace(doc, sections)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		if out, err = prune(out, sections); err != nil {
+			return "", err
+		}
+		return appendMissing(out, sections), nil
+	}
+
+	switch fallback {
+	case Overwrite:
+		s := ""
+		for _, section := range sections {
+			s += section.String()
+		}
+		return s, nil
+	case Append:
+		s := strings.TrimRight(doc, "\n")
+		if s != "" {
+			s += "\n\n"
+		}
+		for _, section := range sections {
+			s += Wrap(section)
+		}
+		return s, nil
+	}
+	return "", fmt.Errorf("invalid fallback %q, expected %q or %q", fallback, Overwrite, Append)
+}
+
+// prune removes the marker pairs, and their content, of the sections that are
+// no longer generated, such as the sections of a deleted workflow.
+func prune(doc string, sections []*markdown.Section) (string, error) {
+	names := map[string]bool{}
+	for _, section := range sections {
+		names[section.Name] = true
+	}
+	for _, match := range startPattern.FindAllStringSubmatch(doc, -1) {
+		name := match[1]
+		if names[name] {
+			continue
+		}
+		start, end := Start(name), End(name)
+		i := strings.Index(doc, start)
+		j := strings.Index(doc[i:], end)
+		if j < 0 {
+			return "", fmt.Errorf("marker %q has no matching %q", start, end)
+		}
+		j += i + len(end)
+		if strings.HasPrefix(doc[j:], "\n") {
+			j++
+		}
+		doc = doc[:i] + doc[j:]
+	}
+	return doc, nil
+}
+
+var startPattern = regexp.MustCompile(`<!-- action-docs:(\S+):start -->`)
+
+// appendMissing adds the sections of every group without markers, wrapped in
+// markers, after the last marker pair of doc. Groups are the prefix of the
+// section names, the workflow file of "deploy.yml:inputs", so that the
+// sections of a new workflow are added while sections left out of a group on
+// purpose are not. Sections without a group are never added.
+func appendMissing(doc string, sections []*markdown.Section) string {
+	marked := map[string]bool{}
+	last := 0
+	for _, section := range sections {
+		if i := strings.Index(doc, End(section.Name)); i >= 0 {
+			marked[group(section.Name)] = true
+			last = max(last, i+len(End(section.Name)))
+		}
+	}
+
+	s := ""
+	for _, section := range sections {
+		if g := group(section.Name); g != "" && !marked[g] {
+			s += Wrap(section)
+		}
+	}
+	if s == "" {
+		return doc
+	}
+	if strings.HasPrefix(doc[last:], "\n") {
+		last++
+	} else {
+		s = "\n" + s
+	}
+	return doc[:last] + s + doc[last:]
+}
+
+// group returns the prefix of a section name, empty when it has none.
+func group(name string) string {
+	i := strings.LastIndex(name, ":")
+	if i < 0 {
+		return ""
+	}
+	return name[:i]
+}
+
+// Anchors returns the anchor GitHub generates for the first heading of every
+// section between markers in doc. Every heading of the document is counted,
+// hand-written ones included, so that repeated headings are numbered as on
+// GitHub. Headings inside fenced code blocks are skipped.
+func Anchors(doc string) map[string]string {
+	anchors := map[string]string{}
+	slugger := markdown.Slugger{}
+	section, fence := "", ""
+	for _, line := range strings.Split(doc, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		case strings.HasPrefix(trimmed, "```"), strings.HasPrefix(trimmed, "~~~"):
+			fence = trimmed[:3]
+			continue
+		case strings.HasPrefix(trimmed, "<!-- action-docs:") && strings.HasSuffix(trimmed, ":start -->"):
+			section = strings.TrimSuffix(strings.TrimPrefix(trimmed, "<!-- action-docs:"), ":start -->")
+			continue
+		case strings.HasPrefix(trimmed, "<!-- action-docs:") && strings.HasSuffix(trimmed, ":end -->"):
+			section = ""
+			continue
+		}
+
+		heading, ok := markdown.ParseHeading(line)
+		if !ok {
+			continue
+		}
+		anchor := slugger.Slug(heading)
+		if _, found := anchors[section]; section != "" && !found {
+			anchors[section] = anchor
+		}
+	}
+	return anchors
+}
@@ -0,0 +1,232 @@
+package inject
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nu12/action-docs/internal/markdown"
+)
+
+const errorf = "Error: %v. \nExpected: %q \nGot: %q"
+
+func sections() []*markdown.Section {
+	usage := markdown.NewSection("usage")
+	usage.Add(markdown.H2("Usage"))
+	inputs := markdown.NewSection("inputs")
+	inputs.Add(markdown.P("New inputs"))
+	return []*markdown.Section{usage, inputs}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		fallback string
+		expected string
+	}{
+		{
+			name:     "Replace between markers",
+			doc:      "# Title\n\n<!-- action-docs:inputs:start -->\nOld inputs\n<!-- action-docs:inputs:end -->\n\n## Troubleshooting\n",
+			fallback: Overwrite,
+			expected: "# Title\n\n<!-- action-docs:inputs:start -->\nNew inputs\n\n<!-- action-docs:inputs:end -->\n\n## Troubleshooting\n",
+		},
+		{
+			name:     "Overwrite without markers",
+			doc:      "# Title\n",
+			fallback: Overwrite,
+			expected: "## Usage\n\nNew inputs\n\n",
+		},
+		{
+			name:     "Append without markers",
+			doc:      "# Title\n",
+			fallback: Append,
+			expected: "# Title\n\n<!-- action-docs:usage:start -->\n## Usage\n\n<!-- action-docs:usage:end -->\n<!-- action-docs:inputs:start -->\nNew inputs\n\n<!-- action-docs:inputs:end -->\n",
+		},
+		{
+			name:     "Append to empty document",
+			doc:      "",
+			fallback: Append,
+			expected: "<!-- action-docs:usage:start -->\n## Usage\n\n<!-- action-docs:usage:end -->\n<!-- action-docs:inputs:start -->\nNew inputs\n\n<!-- action-docs:inputs:end -->\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.doc, sections(), tt.fallback)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf(errorf, "Render doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func workflowSections(files ...string) []*markdown.Section {
+	var sections []*markdown.Section
+	for _, file := range files {
+		header := markdown.NewSection(file + ":header")
+		header.Add(markdown.H2(file))
+		jobs := markdown.NewSection(file + ":jobs")
+		jobs.Add(markdown.P("Jobs of " + file))
+		sections = append(sections, header, jobs)
+	}
+	return sections
+}
+
+func TestRenderWorkflows(t *testing.T) {
+	a := "<!-- action-docs:a.yml:header:start -->\n## a.yml\n\n<!-- action-docs:a.yml:header:end -->\n" +
+		"<!-- action-docs:a.yml:jobs:start -->\nJobs of a.yml\n\n<!-- action-docs:a.yml:jobs:end -->\n"
+	b := "<!-- action-docs:b.yml:header:start -->\n## b.yml\n\n<!-- action-docs:b.yml:header:end -->\n" +
+		"<!-- action-docs:b.yml:jobs:start -->\nJobs of b.yml\n\n<!-- action-docs:b.yml:jobs:end -->\n"
+
+	tests := []struct {
+		name     string
+		doc      string
+		files    []string
+		expected string
+	}{
+		{
+			name:     "Add a workflow",
+			doc:      "# Title\n\n" + a + "\n## Troubleshooting\n",
+			files:    []string{"a.yml", "b.yml"},
+			expected: "# Title\n\n" + a + b + "\n## Troubleshooting\n",
+		},
+		{
+			name:     "Add a workflow at the end of the document",
+			doc:      "# Title\n\n" + strings.TrimSuffix(a, "\n"),
+			files:    []string{"a.yml", "b.yml"},
+			expected: "# Title\n\n" + a + b,
+		},
+		{
+			name:     "Remove a workflow",
+			doc:      "# Title\n\n" + a + b + "\n## Troubleshooting\n",
+			files:    []string{"a.yml"},
+			expected: "# Title\n\n" + a + "\n## Troubleshooting\n",
+		},
+		{
+			name:     "Keep sections left out of a workflow",
+			doc:      "<!-- action-docs:a.yml:jobs:start -->\n<!-- action-docs:a.yml:jobs:end -->\n",
+			files:    []string{"a.yml"},
+			expected: "<!-- action-docs:a.yml:jobs:start -->\nJobs of a.yml\n\n<!-- action-docs:a.yml:jobs:end -->\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.doc, workflowSections(tt.files...), Overwrite)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf(errorf, "Render doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		fallback string
+	}{
+		{
+			name:     "Missing end marker",
+			doc:      "<!-- action-docs:inputs:start -->\n",
+			fallback: Overwrite,
+		},
+		{
+			name:     "Missing start marker",
+			doc:      "<!-- action-docs:inputs:end -->\n",
+			fallback: Overwrite,
+		},
+		{
+			name:     "Missing end marker of a removed section",
+			doc:      "<!-- action-docs:inputs:start -->\n<!-- action-docs:inputs:end -->\n<!-- action-docs:old:start -->\n",
+			fallback: Overwrite,
+		},
+		{
+			name:     "Invalid fallback",
+			doc:      "",
+			fallback: "merge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Render(tt.doc, sections(), tt.fallback); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
+
+func TestStamped(t *testing.T) {
+	stamp := &Stamp{Version: "v1.2.3", Sources: []string{"a.yml", "b.yml"}, Hash: "abc"}
+	line := "<!-- action-docs version=v1.2.3 sources=a.yml,b.yml hash=abc -->\n"
+
+	tests := []struct {
+		name     string
+		doc      string
+		stamp    *Stamp
+		expected string
+	}{
+		{
+			name:     "Add on top",
+			doc:      "# Title\n",
+			stamp:    stamp,
+			expected: line + "# Title\n",
+		},
+		{
+			name:     "Replace previous stamp",
+			doc:      "# Title\n<!-- action-docs version=v1.0.0 sources=a.yml hash=def -->\nText\n",
+			stamp:    stamp,
+			expected: "# Title\n" + line + "Text\n",
+		},
+		{
+			name:     "Previous stamp on the last line",
+			doc:      "# Title\n<!-- action-docs version=v1.0.0 sources=a.yml hash=def -->",
+			stamp:    stamp,
+			expected: "# Title\n" + line,
+		},
+		{
+			name:     "Without stamp",
+			doc:      "# Title\n",
+			expected: "# Title\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Stamped(tt.doc, tt.stamp); got != tt.expected {
+				t.Errorf(errorf, "Stamped doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAnchors(t *testing.T) {
+	doc := "# Workflows\n\n## Deploy\n\nHand-written.\n\n" +
+		"<!-- action-docs:deploy.yml:header:start -->\n## Deploy\n\n<!-- action-docs:deploy.yml:header:end -->\n" +
+		"<!-- action-docs:deploy.yml:call:start -->\n```yaml\n# Not a heading\n```\n#### Inputs\n\n<!-- action-docs:deploy.yml:call:end -->\n" +
+		"## Troubleshooting\n" +
+		"<!-- action-docs:ci.yml:header:start -->\n## Inputs\n\n<!-- action-docs:ci.yml:header:end -->\n" +
+		"<!-- action-docs:toc:start -->\nNo heading\n<!-- action-docs:toc:end -->\n"
+
+	expected := map[string]string{
+		"deploy.yml:header": "deploy-1",
+		"deploy.yml:call":   "inputs",
+		"ci.yml:header":     "inputs-1",
+	}
+	got := Anchors(doc)
+	if len(got) != len(expected) {
+		t.Errorf(errorf, "Anchors size mismatch", expected, got)
+	}
+	for section, anchor := range expected {
+		if got[section] != anchor {
+			t.Errorf(errorf, "Anchor of "+section+" doesn't match", anchor, got[section])
+		}
+	}
+}
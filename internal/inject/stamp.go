@@ -0,0 +1,36 @@
+package inject
+
+import (
+	"fmt"
+	"strings"
+)
+
+const stampPrefix = "<!-- action-docs version="
+
+// Stamp records how a document was generated: the version of action-docs,
+// the source files and the hash of their parsed model.
+type Stamp struct {
+	Version string
+	Sources []string
+	Hash    string
+}
+
+func (s *Stamp) String() string {
+	return fmt.Sprintf("%s%s sources=%s hash=%s -->", stampPrefix, s.Version, strings.Join(s.Sources, ","), s.Hash)
+}
+
+// Stamped returns doc with the stamp on its own line, in place of a previous
+// stamp or at the top of the document. A nil stamp leaves doc unchanged.
+func Stamped(doc string, stamp *Stamp) string {
+	if stamp == nil {
+		return doc
+	}
+	lines := strings.SplitAfter(doc, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, stampPrefix) {
+			lines[i] = stamp.String() + "\n"
+			return strings.Join(lines, "")
+		}
+	}
+	return stamp.String() + "\n" + doc
+}
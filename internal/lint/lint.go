@@ -0,0 +1,139 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/nu12/action-docs/internal/action"
+	"github.com/nu12/action-docs/internal/diagnostic"
+	"github.com/nu12/action-docs/internal/types"
+	"github.com/nu12/action-docs/internal/workflow"
+)
+
+// Rule IDs.
+const (
+	MissingDescription   = "missing-description"
+	InputDescription     = "input-description"
+	OutputDescription    = "output-description"
+	RequiredWithDefault  = "required-with-default"
+	ChoiceWithoutOptions = "choice-without-options"
+	DefaultNotInOptions  = "default-not-in-options"
+	UnusedSecret         = "unused-secret"
+)
+
+type linter struct {
+	file   string
+	source []byte
+	// options decide whether comments document items, as they do when
+	// rendering.
+	options     types.Options
+	diagnostics diagnostic.List
+}
+
+func newLinter(file string, options types.Options) (*linter, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return &linter{file: file, source: b, options: options}, nil
+}
+
+func (l *linter) add(rule, severity, message string, position types.Position) {
+	l.diagnostics = append(l.diagnostics, diagnostic.Diagnostic{
+		File:     l.file,
+		Line:     position.Line,
+		Column:   position.Column,
+		Severity: severity,
+		Message:  message,
+		Rule:     rule,
+	})
+}
+
+func (l *linter) sorted() diagnostic.List {
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		return l.diagnostics[i].Line < l.diagnostics[j].Line
+	})
+	return l.diagnostics
+}
+
+func (l *linter) inputs(inputs *types.InputMap) {
+	for _, input := range inputs.List() {
+		if l.options.Describe(input.Description, input.Comment) == "" {
+			l.add(InputDescription, diagnostic.Error, fmt.Sprintf("input %q has no description", input.Name), input.Position)
+		}
+		if input.Required && !input.Default.IsZero() {
+			l.add(RequiredWithDefault, diagnostic.Warning, fmt.Sprintf("input %q is required but also has a default value", input.Name), input.Position)
+		}
+	}
+}
+
+func (l *linter) outputs(outputs *types.OutputMap) {
+	for _, output := range outputs.List() {
+		if l.options.Describe(output.Description, output.Comment) == "" {
+			l.add(OutputDescription, diagnostic.Error, fmt.Sprintf("output %q has no description", output.Name), output.Position)
+		}
+	}
+}
+
+// top is the position reported for issues of the whole file.
+var top = types.Position{Line: 1, Column: 1}
+
+func Action(a *action.Action) (diagnostic.List, error) {
+	l, err := newLinter(a.Filename, a.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.options.Describe(a.Description, a.Comment) == "" {
+		l.add(MissingDescription, diagnostic.Error, "action has no description", top)
+	}
+	l.inputs(a.Inputs)
+	l.outputs(a.Outputs)
+
+	return l.sorted(), nil
+}
+
+func Workflow(w *workflow.Workflow) (diagnostic.List, error) {
+	l, err := newLinter(w.Filename, w.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.options.Describe(w.Description, w.Comment) == "" {
+		l.add(MissingDescription, diagnostic.Warning, "workflow has no description", top)
+	}
+
+	if call := w.On.WorkflowCall; call != nil {
+		l.inputs(call.Inputs)
+		l.outputs(call.Outputs)
+		for _, secret := range call.Secrets.List() {
+			if !referencesSecret(l.source, secret.Name) {
+				l.add(UnusedSecret, diagnostic.Warning, fmt.Sprintf("secret %q is declared but never used", secret.Name), secret.Position)
+			}
+		}
+	}
+
+	if dispatch := w.On.WorkflowDispatch; dispatch != nil {
+		l.inputs(dispatch.Inputs)
+		for _, input := range dispatch.Inputs.List() {
+			if input.Type != "choice" {
+				continue
+			}
+			if len(input.Options) == 0 {
+				l.add(ChoiceWithoutOptions, diagnostic.Error, fmt.Sprintf("choice input %q has no options", input.Name), input.Position)
+			} else if !input.Default.IsZero() && !slices.Contains(input.Options, input.Default.Value) {
+				l.add(DefaultNotInOptions, diagnostic.Error, fmt.Sprintf("default value %q of choice input %q is not one of its options", input.Default.Value, input.Name), input.Position)
+			}
+		}
+	}
+
+	return l.sorted(), nil
+}
+
+func referencesSecret(source []byte, name string) bool {
+	re := regexp.MustCompile(`(?i)secrets\s*(\.\s*` + regexp.QuoteMeta(name) + `\b|\[\s*['"]` + regexp.QuoteMeta(name) + `['"]\s*\])`)
+	return re.Match(source)
+}
@@ -0,0 +1,243 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nu12/action-docs/internal/action"
+	"github.com/nu12/action-docs/internal/diagnostic"
+	"github.com/nu12/action-docs/internal/types"
+	"github.com/nu12/action-docs/internal/workflow"
+)
+
+const errorf = "Error: %v. \nExpected: %v \nGot: %v"
+
+func TestAction(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		options   types.Options
+		expected  []string
+		hasErrors bool
+	}{
+		{
+			name: "Documented action",
+			data: `name: 'Action'
+description: 'Description'
+inputs:
+  in1:
+    description: 'Input1'
+outputs:
+  out1:
+    description: 'Output1'
+`,
+			expected:  []string{},
+			hasErrors: false,
+		},
+		{
+			name: "Action documented with comments",
+			data: `# Description
+name: 'Action'
+inputs:
+  in1: # Input1
+outputs:
+  # Output1
+  out1:
+    value: 'Hello'
+`,
+			options:   types.Options{DescribeWith: types.DescribeComment},
+			expected:  []string{},
+			hasErrors: false,
+		},
+		{
+			name: "Comments left out",
+			data: `# Description
+name: 'Action'
+inputs:
+  in1: # Input1
+`,
+			expected: []string{
+				"action.yml:1:1: error: action has no description [missing-description]",
+				`action.yml:4:3: error: input "in1" has no description [input-description]`,
+			},
+			hasErrors: true,
+		},
+		{
+			name: "Undocumented action",
+			data: `name: 'Action'
+inputs:
+  in1:
+    required: true
+    default: 'one'
+outputs:
+  out1:
+    value: 'Hello'
+`,
+			expected: []string{
+				"action.yml:1:1: error: action has no description [missing-description]",
+				"action.yml:3:3: error: input \"in1\" has no description [input-description]",
+				"action.yml:3:3: warning: input \"in1\" is required but also has a default value [required-with-default]",
+				"action.yml:7:3: error: output \"out1\" has no description [output-description]",
+			},
+			hasErrors: true,
+		},
+		{
+			name: "Required with empty default",
+			data: `name: 'Action'
+description: 'Action'
+inputs:
+  in1:
+    description: 'Input1'
+    required: true
+    default: ''
+`,
+			expected: []string{
+				"action.yml:4:3: warning: input \"in1\" is required but also has a default value [required-with-default]",
+			},
+			hasErrors: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := t.TempDir() + "/action.yml"
+			if err := os.WriteFile(file, []byte(tt.data), 0644); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			a, diagnostics := action.Parse(file)
+			if len(diagnostics) > 0 {
+				t.Fatalf("error: %v", diagnostics)
+			}
+
+			a.Options = tt.options
+			findings, err := Action(a)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			check(t, findings, tt.expected, tt.hasErrors)
+		})
+	}
+}
+
+func TestWorkflow(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		expected  []string
+		hasErrors bool
+	}{
+		{
+			name: "Documented workflow",
+			data: `name: 'Workflow'
+description: 'Description'
+on:
+  workflow_call:
+    secrets:
+      token:
+        required: true
+jobs:
+  job:
+    runs-on: ubuntu-latest
+    steps:
+    - run: echo ${{ secrets.TOKEN }}
+`,
+			expected:  []string{},
+			hasErrors: false,
+		},
+		{
+			name: "Secret used with index syntax",
+			data: `name: 'Workflow'
+description: 'Description'
+on:
+  workflow_call:
+    secrets:
+      token:
+        required: true
+jobs:
+  job:
+    runs-on: ubuntu-latest
+    steps:
+    - run: echo ${{ secrets['token'] }}
+`,
+			expected:  []string{},
+			hasErrors: false,
+		},
+		{
+			name: "Inconsistent workflow",
+			data: `name: 'Workflow'
+on:
+  workflow_call:
+    inputs:
+      in1:
+        type: string
+    secrets:
+      unused:
+        required: true
+      tokenized:
+        required: false
+  workflow_dispatch:
+    inputs:
+      env:
+        description: 'Environment'
+        type: choice
+      region:
+        description: 'Region'
+        type: choice
+        default: 'eu'
+        options:
+        - us
+        - asia
+jobs:
+  job:
+    runs-on: ubuntu-latest
+    steps:
+    - run: echo ${{ secrets.tokenized_value }}
+`,
+			expected: []string{
+				"call.yml:1:1: warning: workflow has no description [missing-description]",
+				"call.yml:5:7: error: input \"in1\" has no description [input-description]",
+				"call.yml:8:7: warning: secret \"unused\" is declared but never used [unused-secret]",
+				"call.yml:10:7: warning: secret \"tokenized\" is declared but never used [unused-secret]",
+				"call.yml:14:7: error: choice input \"env\" has no options [choice-without-options]",
+				"call.yml:17:7: error: default value \"eu\" of choice input \"region\" is not one of its options [default-not-in-options]",
+			},
+			hasErrors: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := t.TempDir() + "/call.yml"
+			if err := os.WriteFile(file, []byte(tt.data), 0644); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			w, diagnostics := workflow.Parse(file)
+			if len(diagnostics) > 0 {
+				t.Fatalf("error: %v", diagnostics)
+			}
+
+			findings, err := Workflow(w)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			check(t, findings, tt.expected, tt.hasErrors)
+		})
+	}
+}
+
+func check(t *testing.T, findings diagnostic.List, expected []string, hasErrors bool) {
+	t.Helper()
+	if len(findings) != len(expected) {
+		t.Fatalf(errorf, "Findings size mismatch", expected, findings)
+	}
+	for i, finding := range findings {
+		finding.File = filepath.Base(finding.File)
+		if finding.String() != expected[i] {
+			t.Errorf(errorf, "Finding doesn't match", expected[i], finding.String())
+		}
+	}
+	if findings.HasErrors() != hasErrors {
+		t.Errorf(errorf, "HasErrors doesn't match", hasErrors, findings.HasErrors())
+	}
+}
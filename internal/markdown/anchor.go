@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Slug returns the anchor GitHub generates for a heading: the heading in lower
+// case, without punctuation, symbols or emoji, and with hyphens for spaces.
+func Slug(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Slugger generates the anchors of the headings of a document, in order.
+// Repeated headings are suffixed with -1, -2... as GitHub does.
+type Slugger map[string]int
+
+func (s Slugger) Slug(heading string) string {
+	slug := Slug(heading)
+	anchor := slug
+	for {
+		if _, ok := s[anchor]; !ok {
+			break
+		}
+		s[slug]++
+		anchor = slug + "-" + strconv.Itoa(s[slug])
+	}
+	s[anchor] = 0
+	return anchor
+}
+
+// ParseHeading returns the text of an ATX heading line such as "## Inputs".
+func ParseHeading(line string) (string, bool) {
+	indent := len(line) - len(strings.TrimLeft(line, " "))
+	line = strings.TrimLeft(line, " ")
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if indent > 3 || level == 0 || level > 6 {
+		return "", false
+	}
+	text := line[level:]
+	if text != "" && text[0] != ' ' && text[0] != '\t' {
+		return "", false
+	}
+	text = strings.TrimSpace(text)
+	// The closing sequence is optional and isn't part of the text.
+	if trimmed := strings.TrimRight(text, "#"); trimmed == "" || strings.HasSuffix(trimmed, " ") {
+		text = strings.TrimSpace(trimmed)
+	}
+	return text, true
+}
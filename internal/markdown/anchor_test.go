@@ -0,0 +1,74 @@
+package markdown
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    string
+		expected string
+	}{
+		{name: "Spaces", given: "my workflow", expected: "my-workflow"},
+		{name: "Parenthesis", given: "my (workflow)", expected: "my-workflow"},
+		{name: "Lower case", given: "My Workflow", expected: "my-workflow"},
+		{name: "Punctuation", given: "Build, test & deploy!", expected: "build-test--deploy"},
+		{name: "Slashes and dots", given: "ci/cd v1.2", expected: "cicd-v12"},
+		{name: "Hyphens and underscores", given: "pre-commit_checks", expected: "pre-commit_checks"},
+		{name: "Emoji", given: "🚀 Release", expected: "-release"},
+		{name: "Non-ASCII", given: "Déploiement Ünïcode", expected: "déploiement-ünïcode"},
+		{name: "Code", given: "Run `make`", expected: "run-make"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slug(tt.given); got != tt.expected {
+				t.Errorf("Slug doesn't match. Got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSlugger(t *testing.T) {
+	headings := []string{"Inputs", "Inputs", "Inputs-1", "Inputs", "Outputs"}
+	expected := []string{"inputs", "inputs-1", "inputs-1-1", "inputs-2", "outputs"}
+
+	slugger := Slugger{}
+	var got []string
+	for _, heading := range headings {
+		got = append(got, slugger.Slug(heading))
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("Slugs don't match. Got %q, want %q", got, expected)
+	}
+}
+
+func TestParseHeading(t *testing.T) {
+	tests := []struct {
+		given    string
+		expected string
+		ok       bool
+	}{
+		{"# Title", "Title", true},
+		{"#### Usage example", "Usage example", true},
+		{"   ## Indented", "Indented", true},
+		{"## Closed ##", "Closed", true},
+		{"## C#", "C#", true},
+		{"##", "", true},
+		{"#hashtag", "", false},
+		{"    # Code", "", false},
+		{"####### Seven", "", false},
+		{"Text", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			got, ok := ParseHeading(tt.given)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("ParseHeading doesn't match. Got %q, %v, want %q, %v", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
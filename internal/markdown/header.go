@@ -3,6 +3,7 @@ package markdown
 type H1 string
 type H2 string
 type H3 string
+type H4 string
 
 func (h H1) String() string {
 	return "# " + string(h) + "\n\n"
@@ -13,3 +14,6 @@ func (h H2) String() string {
 func (h H3) String() string {
 	return "### " + string(h) + "\n\n"
 }
+func (h H4) String() string {
+	return "#### " + string(h) + "\n\n"
+}
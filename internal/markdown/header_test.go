@@ -30,3 +30,12 @@ func TestH3(t *testing.T) {
 		t.Errorf("H3 doesn't match. Got %q, want %q", result, expected)
 	}
 }
+
+func TestH4(t *testing.T) {
+	h := H4("Hello")
+	expected := "#### Hello\n\n"
+	result := h.String()
+	if result != expected {
+		t.Errorf("H4 doesn't match. Got %q, want %q", result, expected)
+	}
+}
@@ -20,3 +20,21 @@ func (m *Markdown) String() string {
 	}
 	return s
 }
+
+// Headings returns the text of the headings, in order.
+func (m *Markdown) Headings() []string {
+	var headings []string
+	for _, e := range m.Elements {
+		switch h := e.(type) {
+		case H1:
+			headings = append(headings, string(h))
+		case H2:
+			headings = append(headings, string(h))
+		case H3:
+			headings = append(headings, string(h))
+		case H4:
+			headings = append(headings, string(h))
+		}
+	}
+	return headings
+}
@@ -1,6 +1,7 @@
 package markdown
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -15,3 +16,13 @@ func TestMarkdown(t *testing.T) {
 		t.Errorf("Add doesn't match. Got %q, want %q", result, expected)
 	}
 }
+
+func TestHeadings(t *testing.T) {
+	m := &Markdown{}
+	m.Add(H1("Hello")).Add(P("text")).Add(H3("World")).Add(H4("Again"))
+	expected := []string{"Hello", "World", "Again"}
+	result := m.Headings()
+	if !slices.Equal(result, expected) {
+		t.Errorf("Headings don't match. Got %q, want %q", result, expected)
+	}
+}
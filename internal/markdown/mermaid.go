@@ -0,0 +1,8 @@
+package markdown
+
+// Mermaid is a diagram rendered natively by GitHub.
+type Mermaid string
+
+func (m Mermaid) String() string {
+	return "```mermaid\n" + string(m) + "\n```\n\n"
+}
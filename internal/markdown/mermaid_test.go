@@ -0,0 +1,14 @@
+package markdown
+
+import (
+	"testing"
+)
+
+func TestMermaid(t *testing.T) {
+	m := Mermaid("flowchart LR\n  a --> b")
+	expected := "```mermaid\nflowchart LR\n  a --> b\n```\n\n"
+	result := m.String()
+	if result != expected {
+		t.Errorf("Mermaid doesn't match. Got %q, want %q", result, expected)
+	}
+}
@@ -0,0 +1,10 @@
+package markdown
+
+type Section struct {
+	Name string
+	Markdown
+}
+
+func NewSection(name string) *Section {
+	return &Section{Name: name}
+}
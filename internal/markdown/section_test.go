@@ -0,0 +1,17 @@
+package markdown
+
+import (
+	"testing"
+)
+
+func TestSection(t *testing.T) {
+	s := NewSection("usage")
+	s.Add(H2("Usage")).Add(P("Hello"))
+	expected := "## Usage\n\nHello\n\n"
+	if s.Name != "usage" {
+		t.Errorf("Section name doesn't match. Got %q, want %q", s.Name, "usage")
+	}
+	if result := s.String(); result != expected {
+		t.Errorf("Section doesn't match. Got %q, want %q", result, expected)
+	}
+}
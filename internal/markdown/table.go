@@ -1,10 +1,19 @@
 package markdown
 
-import "sort"
+import (
+	"slices"
+	"sort"
+	"strings"
+)
 
 type Table struct {
 	Header Header
 	Rows   []Row
+	// Verbatim lists the columns already holding markdown, such as code spans
+	// or links. Cells of the other columns are escaped as plain text.
+	Verbatim []int
+	// NewLine replaces the line breaks of cells, <br> when empty.
+	NewLine string
 }
 
 type Header []string
@@ -31,8 +40,8 @@ func (t *Table) String() string {
 
 	for _, r := range t.Rows {
 		table += "|"
-		for _, c := range r {
-			table += c + "|"
+		for i, c := range r {
+			table += t.cell(i, c) + "|"
 		}
 		table += "\n"
 	}
@@ -46,3 +55,48 @@ func (t *Table) Sort(columnPosition int) *Table {
 	})
 	return t
 }
+
+// cell escapes the content of a cell so that it can't break the table.
+func (t *Table) cell(column int, s string) string {
+	if !slices.Contains(t.Verbatim, column) {
+		s = Escape(s)
+	}
+	newLine := t.NewLine
+	if newLine == "" {
+		newLine = "<br>"
+	}
+	s = strings.ReplaceAll(strings.TrimRight(s, "\r\n"), "\r\n", "\n")
+	s = strings.ReplaceAll(s, "\n", newLine)
+	return strings.ReplaceAll(s, "|", "\\|")
+}
+
+var escaper = strings.NewReplacer("\\", "\\\\", "<", "&lt;", ">", "&gt;")
+
+// Escape makes text safe to write in markdown: backslashes are kept and
+// HTML-sensitive characters are not taken for tags.
+func Escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// InlineCode renders s as a code span, empty when s is empty. Line breaks
+// become spaces, as they would in any code span.
+func InlineCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	if strings.Contains(s, "\n") {
+		lines := strings.Split(strings.TrimSpace(s), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimSpace(line)
+		}
+		s = strings.Join(lines, " ")
+	}
+	fence := "`"
+	for strings.Contains(s, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(s, "`") || strings.HasSuffix(s, "`") {
+		s = " " + s + " "
+	}
+	return fence + s + fence
+}
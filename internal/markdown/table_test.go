@@ -10,7 +10,7 @@ func TestTable(t *testing.T) {
 		{"Alice", "20"},
 		{"Bob", "30"},
 	}
-	table := Table{header, rows}
+	table := Table{Header: header, Rows: rows}
 
 	expected := "|Name|Age|\n|---|---|\n|Alice|20|\n|Bob|30|\n\n"
 
@@ -26,7 +26,7 @@ func TestSort(t *testing.T) {
 		{"Bob", "30"},
 		{"Alice", "20"},
 	}
-	table := Table{header, rows}
+	table := Table{Header: header, Rows: rows}
 
 	expected := "|Name|Age|\n|---|---|\n|Alice|20|\n|Bob|30|\n\n"
 
@@ -41,7 +41,7 @@ func TestAddRow(t *testing.T) {
 	rows := []Row{
 		{"Alice", "20"},
 	}
-	table := Table{header, rows}
+	table := Table{Header: header, Rows: rows}
 	table.AddRow(Row{"Bob", "30"})
 
 	expected := "|Name|Age|\n|---|---|\n|Alice|20|\n|Bob|30|\n\n"
@@ -50,3 +50,49 @@ func TestAddRow(t *testing.T) {
 		t.Errorf("Table doesn't match. Got %q, want %q", result, expected)
 	}
 }
+
+func TestCellEscaping(t *testing.T) {
+	table := Table{
+		Header:   Header{"Name", "Markdown"},
+		Verbatim: []int{1},
+	}
+	table.AddRow(Row{"a|b \\ <c>", "`x|y`"})
+	table.AddRow(Row{"line 1\nline 2\n", "<pre>1<br>2</pre>"})
+
+	expected := "|Name|Markdown|\n|---|---|\n" +
+		"|a\\|b \\\\ &lt;c&gt;|`x\\|y`|\n" +
+		"|line 1<br>line 2|<pre>1<br>2</pre>|\n\n"
+	if result := table.String(); result != expected {
+		t.Errorf("Table doesn't match. Got %q, want %q", result, expected)
+	}
+
+	table.NewLine = " "
+	expected = "|Name|Markdown|\n|---|---|\n" +
+		"|a\\|b \\\\ &lt;c&gt;|`x\\|y`|\n" +
+		"|line 1 line 2|<pre>1<br>2</pre>|\n\n"
+	if result := table.String(); result != expected {
+		t.Errorf("Table doesn't match. Got %q, want %q", result, expected)
+	}
+}
+
+func TestInlineCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    string
+		expected string
+	}{
+		{"Empty", "", ""},
+		{"Text", "main", "`main`"},
+		{"Backticks", "a`b", "``a`b``"},
+		{"Leading backtick", "`a", "`` `a ``"},
+		{"Line breaks", "a &&\n  b", "`a && b`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InlineCode(tt.given); got != tt.expected {
+				t.Errorf("InlineCode doesn't match. Got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/nu12/action-docs/internal/markdown"
+)
+
+// Funcs are available to every template, on top of the text/template builtins.
+var Funcs = template.FuncMap{
+	"h1":      func(s string) string { return markdown.H1(s).String() },
+	"h2":      func(s string) string { return markdown.H2(s).String() },
+	"h3":      func(s string) string { return markdown.H3(s).String() },
+	"h4":      func(s string) string { return markdown.H4(s).String() },
+	"p":       func(s string) string { return markdown.P(s).String() },
+	"code":    func(s string) string { return markdown.Code(s).String() },
+	"mermaid": func(s string) string { return markdown.Mermaid(s).String() },
+	"link": func(text, url string) string {
+		return (&markdown.Hyperlink{Text: text, URL: url}).String()
+	},
+	"anchor":  func(s string) string { return "#" + markdown.Slug(s) },
+	"join":    strings.Join,
+	"lower":   strings.ToLower,
+	"upper":   strings.ToUpper,
+	"trim":    strings.TrimSpace,
+	"replace": strings.ReplaceAll,
+	"indent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		return pad + strings.ReplaceAll(s, "\n", "\n"+pad)
+	},
+	"default": func(fallback, s string) string {
+		if s == "" {
+			return fallback
+		}
+		return s
+	},
+}
+
+func New(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=zero").Funcs(Funcs).Parse(text)
+}
+
+func ParseFile(file string) (*template.Template, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return New(filepath.Base(file), string(b))
+}
+
+func Execute(t *template.Template, data any) (string, error) {
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
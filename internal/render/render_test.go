@@ -0,0 +1,76 @@
+package render
+
+import (
+	"os"
+	"testing"
+)
+
+const errorf = "Error: %v. \nExpected: %q \nGot: %q"
+
+func TestExecute(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     any
+		expected string
+	}{
+		{
+			name:     "Headings and paragraphs",
+			template: `{{ h1 .Name }}{{ p .Description }}`,
+			data:     map[string]string{"Name": "My action", "Description": "Does things"},
+			expected: "# My action\n\nDoes things\n\n",
+		},
+		{
+			name:     "Link to anchor",
+			template: `{{ link .Name (anchor .Name) }}`,
+			data:     map[string]string{"Name": "My (workflow)"},
+			expected: "[My (workflow)](#my-workflow)",
+		},
+		{
+			name:     "Missing keys are empty",
+			template: `{{ default "none" .Missing }}`,
+			data:     map[string]string{},
+			expected: "none",
+		},
+		{
+			name:     "Indent",
+			template: `{{ indent 2 .Text }}`,
+			data:     map[string]string{"Text": "a\nb"},
+			expected: "  a\n  b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := New(tt.name, tt.template)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			got, err := Execute(tmpl, tt.data)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf(errorf, "Output doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseFile(t *testing.T) {
+	file := t.TempDir() + "/custom.md.tmpl"
+	if err := os.WriteFile(file, []byte("{{ .Name }}"), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	tmpl, err := ParseFile(file)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if tmpl.Name() != "custom.md.tmpl" {
+		t.Errorf(errorf, "Name doesn't match", "custom.md.tmpl", tmpl.Name())
+	}
+
+	if _, err := ParseFile(t.TempDir() + "/missing.tmpl"); err == nil {
+		t.Errorf("error: %s", "Missing file should fail")
+	}
+}
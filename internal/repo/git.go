@@ -0,0 +1,369 @@
+package repo
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// git reads the metadata of a checkout directly from its git directory.
+type git struct {
+	// dir holds HEAD, common holds the config, the refs and the objects. They
+	// differ for worktrees.
+	dir, common string
+	packs       []*pack
+	packsLoaded bool
+}
+
+func open(root string) (*git, error) {
+	dir := filepath.Join(root, ".git")
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		// Worktrees and submodules have a .git file pointing to the git directory.
+		b, err := os.ReadFile(dir)
+		if err != nil {
+			return nil, err
+		}
+		dir = strings.TrimSpace(strings.TrimPrefix(string(b), "gitdir:"))
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+	}
+
+	g := &git{dir: dir, common: dir}
+	if b, err := os.ReadFile(filepath.Join(dir, "commondir")); err == nil {
+		g.common = strings.TrimSpace(string(b))
+		if !filepath.IsAbs(g.common) {
+			g.common = filepath.Join(dir, g.common)
+		}
+	}
+	return g, nil
+}
+
+// origin returns the URL of the origin remote, empty when there is none.
+func (g *git) origin() string {
+	f, err := os.Open(filepath.Join(g.common, "config"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	inOrigin := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			inOrigin = line == `[remote "origin"]`
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if inOrigin && ok && strings.TrimSpace(key) == "url" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+// head returns the commit checked out, empty when it can't be resolved.
+func (g *git) head() string {
+	b, err := os.ReadFile(filepath.Join(g.dir, "HEAD"))
+	if err != nil {
+		return ""
+	}
+	head := strings.TrimSpace(string(b))
+	if ref, ok := strings.CutPrefix(head, "ref: "); ok {
+		return g.resolve(ref)
+	}
+	return head
+}
+
+// resolve returns the object a ref points to.
+func (g *git) resolve(ref string) string {
+	if b, err := os.ReadFile(filepath.Join(g.common, filepath.FromSlash(ref))); err == nil {
+		return strings.TrimSpace(string(b))
+	}
+	for _, r := range g.packedRefs() {
+		if r.name == ref {
+			return r.object
+		}
+	}
+	return ""
+}
+
+type ref struct {
+	name, object string
+	// peeled is the commit of annotated tags, when known.
+	peeled string
+}
+
+func (g *git) packedRefs() []ref {
+	b, err := os.ReadFile(filepath.Join(g.common, "packed-refs"))
+	if err != nil {
+		return nil
+	}
+	var refs []ref
+	for _, line := range strings.Split(string(b), "\n") {
+		switch {
+		case strings.HasPrefix(line, "#") || line == "":
+		case strings.HasPrefix(line, "^"):
+			if len(refs) > 0 {
+				refs[len(refs)-1].peeled = strings.TrimSpace(line[1:])
+			}
+		default:
+			if object, name, ok := strings.Cut(line, " "); ok {
+				refs = append(refs, ref{name: strings.TrimSpace(name), object: object})
+			}
+		}
+	}
+	return refs
+}
+
+// tags returns the commit of every tag, loose tags taking precedence over
+// packed ones.
+func (g *git) tags() map[string]string {
+	tags := map[string]string{}
+	for _, r := range g.packedRefs() {
+		if name, ok := strings.CutPrefix(r.name, "refs/tags/"); ok {
+			tags[name] = r.peeled
+			if r.peeled == "" {
+				tags[name] = g.peel(r.object)
+			}
+		}
+	}
+	root := filepath.Join(g.common, "refs", "tags")
+	_ = filepath.WalkDir(root, func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil
+		}
+		name, _ := filepath.Rel(root, file)
+		tags[filepath.ToSlash(name)] = g.peel(strings.TrimSpace(string(b)))
+		return nil
+	})
+	return tags
+}
+
+// peel returns the commit an annotated tag points to. Other objects are
+// returned unchanged.
+func (g *git) peel(id string) string {
+	for i := 0; i < 10; i++ {
+		kind, data, err := g.object(id)
+		if err != nil || kind != "tag" {
+			return id
+		}
+		target, ok := bytes.CutPrefix(data, []byte("object "))
+		if !ok {
+			return id
+		}
+		id, _, _ = strings.Cut(string(target), "\n")
+	}
+	return id
+}
+
+// parents returns the parents of a commit, none when it can't be read, as in
+// shallow clones.
+func (g *git) parents(commit string) []string {
+	kind, data, err := g.object(commit)
+	if err != nil || kind != "commit" {
+		return nil
+	}
+	var parents []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			break
+		}
+		if parent, ok := strings.CutPrefix(line, "parent "); ok {
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
+
+// object returns the type and content of an object, from the loose objects
+// or the pack files.
+func (g *git) object(id string) (string, []byte, error) {
+	if len(id) == 40 {
+		if kind, data, err := g.looseObject(id); err == nil {
+			return kind, data, nil
+		}
+	}
+	raw, err := hex.DecodeString(id)
+	if err != nil || len(raw) != 20 {
+		return "", nil, fmt.Errorf("invalid object id %q", id)
+	}
+	for _, p := range g.loadPacks() {
+		if offset, ok := p.find(raw); ok {
+			return p.read(g, offset)
+		}
+	}
+	return "", nil, fmt.Errorf("object %s not found", id)
+}
+
+func (g *git) looseObject(id string) (string, []byte, error) {
+	f, err := os.Open(filepath.Join(g.common, "objects", id[:2], id[2:]))
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+	r, err := zlib.NewReader(f)
+	if err != nil {
+		return "", nil, err
+	}
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", nil, err
+	}
+	header, data, ok := bytes.Cut(b, []byte{0})
+	if !ok {
+		return "", nil, fmt.Errorf("invalid object %s", id)
+	}
+	kind, _, _ := strings.Cut(string(header), " ")
+	return kind, data, nil
+}
+
+func (g *git) loadPacks() []*pack {
+	if g.packsLoaded {
+		return g.packs
+	}
+	g.packsLoaded = true
+	indexes, _ := filepath.Glob(filepath.Join(g.common, "objects", "pack", "*.idx"))
+	for _, idx := range indexes {
+		if p, err := openPack(idx); err == nil {
+			g.packs = append(g.packs, p)
+		}
+	}
+	return g.packs
+}
+
+// shallow reports whether the history of the checkout is truncated.
+func (g *git) shallow() bool {
+	_, err := os.Stat(filepath.Join(g.common, "shallow"))
+	return err == nil
+}
+
+func (g *git) close() {
+	for _, p := range g.packs {
+		p.f.Close()
+	}
+}
+
+// latestTag returns the latest tag reachable from HEAD, as git describe does:
+// the tag of HEAD, preferring versions, or else the highest version tag of the
+// nearest commit with one in its history, walked breadth first. The walk stops
+// at the first version tag, and without version tags the nearest tag is
+// returned. Empty when no tag is
+// reachable, so that usage examples fall back to main instead of a tag of an
+// unmerged branch.
+func (g *git) latestTag() string {
+	tagged := map[string][]string{}
+	for name, commit := range g.tags() {
+		tagged[commit] = append(tagged[commit], name)
+	}
+
+	head := g.head()
+	nearest := ""
+	seen := map[string]bool{}
+	queue := []string{head}
+	for len(queue) > 0 && len(tagged) > 0 {
+		commit := queue[0]
+		queue = queue[1:]
+		if commit == "" || seen[commit] {
+			continue
+		}
+		seen[commit] = true
+
+		if names, ok := tagged[commit]; ok {
+			if tag := highest(names); tag != "" {
+				return tag
+			}
+			if commit == head {
+				return slices.Min(names)
+			}
+			if nearest == "" {
+				nearest = slices.Min(names)
+			}
+			delete(tagged, commit)
+		}
+		queue = append(queue, g.parents(commit)...)
+	}
+	return nearest
+}
+
+// highest returns the highest version among the tags, ignoring the tags that
+// aren't versions such as v1.2.3 or 1.2.
+func highest(tags []string) string {
+	best, bestVersion := "", version{}
+	for _, tag := range tags {
+		v, ok := parseVersion(tag)
+		if !ok {
+			continue
+		}
+		if best == "" || v.compare(bestVersion) > 0 || (v.compare(bestVersion) == 0 && tag < best) {
+			best, bestVersion = tag, v
+		}
+	}
+	return best
+}
+
+type version struct {
+	numbers    []int
+	prerelease string
+}
+
+func parseVersion(tag string) (version, bool) {
+	s, prerelease, _ := strings.Cut(strings.TrimPrefix(tag, "v"), "-")
+	var v version
+	for _, part := range strings.Split(s, ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+		v.numbers = append(v.numbers, n)
+	}
+	v.prerelease = prerelease
+	return v, true
+}
+
+// compare orders versions by number, releases after their prereleases.
+func (v version) compare(other version) int {
+	for i := 0; i < len(v.numbers) || i < len(other.numbers); i++ {
+		a, b := 0, 0
+		if i < len(v.numbers) {
+			a = v.numbers[i]
+		}
+		if i < len(other.numbers) {
+			b = other.numbers[i]
+		}
+		if a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	}
+	return strings.Compare(v.prerelease, other.prerelease)
+}
@@ -0,0 +1,210 @@
+package repo
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// pack is a pack file of objects along with its version 2 index.
+type pack struct {
+	f *os.File
+	// ids are the sorted object ids of the pack, 20 bytes each, and offsets
+	// their position in the pack. Offsets with the high bit set point to
+	// large, the 8-byte offsets of packs over 2 GiB.
+	ids, offsets, large []byte
+}
+
+func openPack(idx string) (*pack, error) {
+	b, err := os.ReadFile(idx)
+	if err != nil {
+		return nil, err
+	}
+	const header = 8 + 256*4
+	if len(b) < header || !bytes.Equal(b[:4], []byte("\xfftOc")) || binary.BigEndian.Uint32(b[4:8]) != 2 {
+		return nil, fmt.Errorf("%s: unsupported pack index", idx)
+	}
+	n := int(binary.BigEndian.Uint32(b[header-4:]))
+	ids := header
+	offsets := ids + n*20 + n*4
+	large := offsets + n*4
+	if len(b) < large {
+		return nil, fmt.Errorf("%s: truncated pack index", idx)
+	}
+	f, err := os.Open(strings.TrimSuffix(idx, ".idx") + ".pack")
+	if err != nil {
+		return nil, err
+	}
+	return &pack{f: f, ids: b[ids : ids+n*20], offsets: b[offsets:large], large: b[large:]}, nil
+}
+
+// find returns the position of an object in the pack.
+func (p *pack) find(id []byte) (int64, bool) {
+	n := len(p.ids) / 20
+	i := sort.Search(n, func(i int) bool { return bytes.Compare(p.ids[i*20:i*20+20], id) >= 0 })
+	if i == n || !bytes.Equal(p.ids[i*20:i*20+20], id) {
+		return 0, false
+	}
+	offset := binary.BigEndian.Uint32(p.offsets[i*4:])
+	if offset&0x80000000 == 0 {
+		return int64(offset), true
+	}
+	j := int(offset&0x7fffffff) * 8
+	if len(p.large) < j+8 {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(p.large[j:])), true
+}
+
+var packTypes = map[byte]string{1: "commit", 2: "tree", 3: "blob", 4: "tag"}
+
+const (
+	offsetDelta = 6
+	refDelta    = 7
+)
+
+// read returns the type and content of the object at offset, resolving
+// deltas against their base object.
+func (p *pack) read(g *git, offset int64) (string, []byte, error) {
+	r := bufio.NewReader(io.NewSectionReader(p.f, offset, 1<<62))
+	c, err := r.ReadByte()
+	if err != nil {
+		return "", nil, err
+	}
+	kind, size, shift := (c>>4)&7, int64(c&15), 4
+	for c&0x80 != 0 {
+		if c, err = r.ReadByte(); err != nil {
+			return "", nil, err
+		}
+		size |= int64(c&0x7f) << shift
+		shift += 7
+	}
+
+	var baseKind string
+	var base []byte
+	switch kind {
+	case offsetDelta:
+		if c, err = r.ReadByte(); err != nil {
+			return "", nil, err
+		}
+		distance := int64(c & 0x7f)
+		for c&0x80 != 0 {
+			if c, err = r.ReadByte(); err != nil {
+				return "", nil, err
+			}
+			distance = (distance+1)<<7 | int64(c&0x7f)
+		}
+		if distance <= 0 || distance > offset {
+			return "", nil, errors.New("invalid delta offset")
+		}
+		if baseKind, base, err = p.read(g, offset-distance); err != nil {
+			return "", nil, err
+		}
+	case refDelta:
+		id := make([]byte, 20)
+		if _, err := io.ReadFull(r, id); err != nil {
+			return "", nil, err
+		}
+		if baseKind, base, err = g.object(hex.EncodeToString(id)); err != nil {
+			return "", nil, err
+		}
+	}
+
+	data, err := inflate(r, size)
+	if err != nil {
+		return "", nil, err
+	}
+	if base != nil {
+		data, err = applyDelta(base, data)
+		return baseKind, data, err
+	}
+	name, ok := packTypes[kind]
+	if !ok {
+		return "", nil, fmt.Errorf("invalid object type %d", kind)
+	}
+	return name, data, nil
+}
+
+func inflate(r io.Reader, size int64) ([]byte, error) {
+	z, err := zlib.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer z.Close()
+	data := make([]byte, size)
+	_, err = io.ReadFull(z, data)
+	return data, err
+}
+
+// applyDelta rebuilds an object from its base and a delta of copy and insert
+// instructions.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	errInvalid := errors.New("invalid delta")
+	baseSize, delta := deltaSize(delta)
+	if baseSize != len(base) {
+		return nil, errInvalid
+	}
+	size, delta := deltaSize(delta)
+	out := make([]byte, 0, size)
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+		switch {
+		case op&0x80 != 0:
+			var offset, n int
+			for i := 0; i < 7; i++ {
+				if op&(1<<i) == 0 {
+					continue
+				}
+				if len(delta) == 0 {
+					return nil, errInvalid
+				}
+				if i < 4 {
+					offset |= int(delta[0]) << (8 * i)
+				} else {
+					n |= int(delta[0]) << (8 * (i - 4))
+				}
+				delta = delta[1:]
+			}
+			if n == 0 {
+				n = 0x10000
+			}
+			if offset+n > len(base) {
+				return nil, errInvalid
+			}
+			out = append(out, base[offset:offset+n]...)
+		case op != 0:
+			if int(op) > len(delta) {
+				return nil, errInvalid
+			}
+			out = append(out, delta[:op]...)
+			delta = delta[op:]
+		default:
+			return nil, errInvalid
+		}
+	}
+	if len(out) != size {
+		return nil, errInvalid
+	}
+	return out, nil
+}
+
+func deltaSize(b []byte) (int, []byte) {
+	size, shift := 0, 0
+	for i, c := range b {
+		size |= int(c&0x7f) << shift
+		shift += 7
+		if c&0x80 == 0 {
+			return size, b[i+1:]
+		}
+	}
+	return size, nil
+}
@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type Info struct {
+	// Root is the top-level directory of the git checkout, empty outside of one.
+	Root string
+	// Slug is the owner and name of the repository, e.g. "nu12/action-docs",
+	// read from the origin remote. Empty when unknown.
+	Slug string
+	// Ref is the version the usage examples point to, the latest tag reachable
+	// from HEAD by default. Empty when unknown.
+	Ref string
+	// Shallow reports whether the checkout is a shallow clone, whose history
+	// may not reach any tag.
+	Shallow bool
+}
+
+// Detect looks for the git checkout containing dir, along with its origin
+// repository and latest tag.
+func Detect(dir string) Info {
+	root := Root(dir)
+	if root == "" {
+		return Info{}
+	}
+	info := Info{Root: root}
+	if g, err := open(root); err == nil {
+		info.Slug = Slug(g.origin())
+		info.Ref = g.latestTag()
+		info.Shallow = g.shallow()
+		g.close()
+	}
+	return info
+}
+
+// Root returns the top-level directory of the git checkout containing dir,
+// empty outside of one.
+func Root(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(abs, ".git")); err == nil {
+			return abs
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return ""
+		}
+		abs = parent
+	}
+}
+
+// Rel returns file relative to the repository root, using forward slashes.
+// Files outside of a repository are returned unchanged.
+func (i Info) Rel(file string) string {
+	if i.Root == "" {
+		return filepath.ToSlash(file)
+	}
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	rel, err := filepath.Rel(i.Root, abs)
+	if err != nil {
+		return filepath.ToSlash(file)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// Uses returns the reference of the action or reusable workflow at file for a
+// `uses` key, e.g. "nu12/action-docs/actions/build@v1.2.3". Without a slug,
+// file is used relative to the repository root, or as is outside of one, and
+// without a ref, main is.
+func (i Info) Uses(file string) string {
+	ref := i.Ref
+	if ref == "" {
+		ref = "main"
+	}
+	if i.Slug == "" {
+		if i.Root != "" {
+			if file = path.Clean(i.Rel(file)); file != "." {
+				file = "./" + file
+			}
+		}
+		return file + "@" + ref
+	}
+	target := i.Slug
+	if rel := path.Clean(i.Rel(file)); rel != "." {
+		target += "/" + strings.TrimPrefix(rel, "/")
+	}
+	return target + "@" + ref
+}
+
+// Slug returns the owner and name of the repository at a remote URL, empty
+// when url has none. HTTPS, SSH and scp-like URLs are supported.
+func Slug(url string) string {
+	url = strings.TrimSuffix(strings.TrimSpace(url), "/")
+	url = strings.TrimSuffix(url, ".git")
+	if i := strings.Index(url, "://"); i >= 0 {
+		url = url[i+3:]
+		j := strings.Index(url, "/")
+		if j < 0 {
+			return ""
+		}
+		url = url[j+1:]
+	} else if i := strings.Index(url, ":"); i >= 0 {
+		url = url[i+1:]
+	} else {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(url, "/"), "/")
+	if len(parts) < 2 || parts[len(parts)-2] == "" || parts[len(parts)-1] == "" {
+		return ""
+	}
+	return parts[len(parts)-2] + "/" + parts[len(parts)-1]
+}
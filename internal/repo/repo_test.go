@@ -0,0 +1,277 @@
+package repo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+const errorf = "Error: %v. \nExpected: %v \nGot: %v"
+
+func TestDetect(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(root+"/.git", 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if err := os.MkdirAll(root+"/actions/build", 0755); err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+
+	info := Detect(root + "/actions/build")
+	if info.Root != root {
+		t.Errorf(errorf, "Root doesn't match", root, info.Root)
+	}
+	if got := info.Rel(root + "/actions/build/action.yml"); got != "actions/build/action.yml" {
+		t.Errorf(errorf, "Relative path doesn't match", "actions/build/action.yml", got)
+	}
+
+	outside := Detect(t.TempDir())
+	if outside.Root != "" {
+		t.Errorf(errorf, "Root should be empty", "", outside.Root)
+	}
+	if got := outside.Rel("actions/build/action.yml"); got != "actions/build/action.yml" {
+		t.Errorf(errorf, "Relative path doesn't match", "actions/build/action.yml", got)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := []struct {
+		given    string
+		expected string
+	}{
+		{"https://github.com/nu12/action-docs.git", "nu12/action-docs"},
+		{"https://github.com/nu12/action-docs", "nu12/action-docs"},
+		{"https://token@github.com/nu12/action-docs/", "nu12/action-docs"},
+		{"git@github.com:nu12/action-docs.git", "nu12/action-docs"},
+		{"ssh://git@github.com:22/nu12/action-docs.git", "nu12/action-docs"},
+		{"https://github.com/nu12", ""},
+		{"/srv/git/action-docs", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.given, func(t *testing.T) {
+			if got := Slug(tt.given); got != tt.expected {
+				t.Errorf(errorf, "Slug doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestUses(t *testing.T) {
+	root := t.TempDir()
+	tests := []struct {
+		name     string
+		info     Info
+		file     string
+		expected string
+	}{
+		{name: "Unknown repository", info: Info{}, file: "./actions/build", expected: "./actions/build@main"},
+		{name: "Unknown repository with ref", info: Info{Ref: "v1"}, file: ".github/workflows/ci.yml", expected: ".github/workflows/ci.yml@v1"},
+		{name: "Checkout without remote", info: Info{Root: root}, file: root + "/actions/build", expected: "./actions/build@main"},
+		{name: "Action", info: Info{Root: root, Slug: "acme/tools", Ref: "v1.2.3"}, file: root + "/actions/build", expected: "acme/tools/actions/build@v1.2.3"},
+		{name: "Root action", info: Info{Root: root, Slug: "acme/tools", Ref: "v1.2.3"}, file: root, expected: "acme/tools@v1.2.3"},
+		{name: "Without checkout", info: Info{Slug: "acme/tools"}, file: "./.github/workflows/ci.yml", expected: "acme/tools/.github/workflows/ci.yml@main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.info.Uses(tt.file); got != tt.expected {
+				t.Errorf(errorf, "Uses doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDetectGit(t *testing.T) {
+	const (
+		head   = "1111111111111111111111111111111111111111"
+		older  = "2222222222222222222222222222222222222222"
+		object = "3333333333333333333333333333333333333333"
+	)
+
+	tests := []struct {
+		name     string
+		files    map[string]string
+		expected Info
+	}{
+		{
+			name: "Tag of HEAD",
+			files: map[string]string{
+				"HEAD":            "ref: refs/heads/main\n",
+				"config":          "[core]\n\tbare = false\n[remote \"upstream\"]\n\turl = https://github.com/other/tools.git\n[remote \"origin\"]\n\turl = git@github.com:acme/tools.git\n",
+				"refs/heads/main": head + "\n",
+				"packed-refs":     "# pack-refs with: peeled fully-peeled sorted\n" + older + " refs/tags/v2.0.0\n" + head + " refs/tags/v1.2.0\n" + object + " refs/tags/v1.10.0\n^" + head + "\n",
+			},
+			expected: Info{Slug: "acme/tools", Ref: "v1.10.0"},
+		},
+		{
+			name: "Unreachable tags",
+			files: map[string]string{
+				"HEAD":             "ref: refs/heads/main\n",
+				"config":           "[remote \"origin\"]\n\turl = https://github.com/acme/tools\n",
+				"packed-refs":      head + " refs/heads/main\n" + older + " refs/tags/v2.0.0-rc.1\n" + older + " refs/tags/v1.9.0\n" + older + " refs/tags/latest\n",
+				"refs/tags/v2.0.0": older + "\n",
+			},
+			expected: Info{Slug: "acme/tools"},
+		},
+		{
+			name: "Detached HEAD without remote or tags",
+			files: map[string]string{
+				"HEAD": head + "\n",
+			},
+			expected: Info{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			for name, content := range tt.files {
+				file := filepath.Join(root, ".git", filepath.FromSlash(name))
+				if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+					t.Fatalf("error: %v", err)
+				}
+				if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+					t.Fatalf("error: %v", err)
+				}
+			}
+
+			tt.expected.Root = root
+			if got := Detect(root); got != tt.expected {
+				t.Errorf(errorf, "Info doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDetectAnnotatedTag(t *testing.T) {
+	const (
+		head = "1111111111111111111111111111111111111111"
+		tag  = "4444444444444444444444444444444444444444"
+	)
+	root := t.TempDir()
+	git := filepath.Join(root, "git")
+	for name, content := range map[string]string{
+		"HEAD":             head + "\n",
+		"refs/tags/v1.0.0": tag + "\n",
+		"refs/tags/v0.1.0": head + "\n",
+	} {
+		file := filepath.Join(git, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("error: %v", err)
+		}
+	}
+
+	// Loose annotated tag pointing to HEAD.
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	content := "object " + head + "\ntype commit\ntag v1.0.0\n\nRelease\n"
+	fmt.Fprintf(w, "tag %d\x00%s", len(content), content)
+	w.Close()
+	if err := os.MkdirAll(filepath.Join(git, "objects", tag[:2]), 0755); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(git, "objects", tag[:2], tag[2:]), b.Bytes(), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	// Worktree-like checkout with a .git file.
+	checkout := filepath.Join(root, "checkout")
+	if err := os.MkdirAll(checkout, 0755); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(checkout, ".git"), []byte("gitdir: ../git\n"), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	if got := Detect(checkout); got.Ref != "v1.0.0" {
+		t.Errorf(errorf, "Ref doesn't match", "v1.0.0", got.Ref)
+	}
+}
+
+func TestDetectHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	root := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com", "-c", "tag.gpgSign=false", "-c", "commit.gpgSign=false"}, args...)...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	run("init", "-q", "-b", "main")
+	run("remote", "add", "origin", "https://github.com/acme/tools.git")
+	run("commit", "-q", "--allow-empty", "-m", "first")
+	// Higher versions past the nearest one are ignored.
+	run("tag", "v3.0.0")
+	run("commit", "-q", "--allow-empty", "-m", "second")
+	run("tag", "-a", "v1.2.3", "-m", "Release")
+	run("checkout", "-q", "-b", "next")
+	run("commit", "-q", "--allow-empty", "-m", "next")
+	run("tag", "v9.0.0")
+	run("checkout", "-q", "main")
+	run("commit", "-q", "--allow-empty", "-m", "fix")
+	run("tag", "nightly")
+
+	for _, step := range []struct {
+		name string
+		args []string
+	}{
+		{name: "Loose objects"},
+		{name: "Pack files", args: []string{"gc", "-q", "--aggressive"}},
+	} {
+		t.Run(step.name, func(t *testing.T) {
+			if step.args != nil {
+				run(step.args...)
+			}
+			if got := Detect(root); got.Slug != "acme/tools" || got.Ref != "nightly" {
+				t.Errorf(errorf, "Info doesn't match", "acme/tools nightly", got.Slug+" "+got.Ref)
+			}
+			run("tag", "-d", "nightly")
+			if got := Detect(root); got.Ref != "v1.2.3" {
+				t.Errorf(errorf, "Ref doesn't match", "v1.2.3", got.Ref)
+			}
+			run("tag", "nightly")
+		})
+	}
+
+	t.Run("Shallow clone", func(t *testing.T) {
+		clone := filepath.Join(t.TempDir(), "clone")
+		run("tag", "-d", "nightly")
+		run("clone", "-q", "--depth", "1", "file://"+root, clone)
+		if got := Detect(clone); !got.Shallow || got.Ref != "" {
+			t.Errorf(errorf, "Info doesn't match", Info{Root: clone, Shallow: true}, got)
+		}
+		if got := Detect(root); got.Shallow {
+			t.Errorf(errorf, "Full clone is not shallow", false, got.Shallow)
+		}
+	})
+}
+
+func TestApplyDelta(t *testing.T) {
+	base := []byte("tree 1234\nparent abcd\n")
+	// Sizes, copy of the first 10 bytes of base, insert of "efgh".
+	delta := []byte{byte(len(base)), 14, 0x90, 10, 4, 'e', 'f', 'g', 'h'}
+	got, err := applyDelta(base, delta)
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if string(got) != "tree 1234\nefgh" {
+		t.Errorf(errorf, "Object doesn't match", "tree 1234\nefgh", string(got))
+	}
+
+	if _, err := applyDelta(base, []byte{1, 1, 1, 'a'}); err == nil {
+		t.Errorf(errorf, "Delta of another base should fail", "error", nil)
+	}
+}
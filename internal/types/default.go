@@ -0,0 +1,104 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/nu12/action-docs/internal/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+// Kind is the kind of value of an input default.
+type Kind string
+
+const (
+	KindString     Kind = "string"
+	KindBool       Kind = "boolean"
+	KindNumber     Kind = "number"
+	KindMultiline  Kind = "multiline"
+	KindExpression Kind = "expression"
+)
+
+// Default is the default value of an input, as written in the YAML file. The
+// zero value is an input without default.
+type Default struct {
+	Value string `json:"value"`
+	Kind  Kind   `json:"kind"`
+}
+
+func (d *Default) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		var s string
+		return node.Decode(&s)
+	}
+
+	switch node.Tag {
+	case "!!null":
+		*d = Default{}
+		return nil
+	case "!!bool":
+		*d = Default{Value: node.Value, Kind: KindBool}
+	case "!!int", "!!float":
+		*d = Default{Value: node.Value, Kind: KindNumber}
+	default:
+		*d = Default{Value: node.Value, Kind: KindString}
+		if strings.Contains(strings.TrimRight(node.Value, "\n"), "\n") {
+			d.Kind = KindMultiline
+		} else if strings.Contains(node.Value, "${{") {
+			d.Kind = KindExpression
+		}
+	}
+	return nil
+}
+
+func (d Default) String() string {
+	return d.Value
+}
+
+// IsZero reports whether the input has no default.
+func (d Default) IsZero() bool {
+	return d.Kind == ""
+}
+
+var preEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\n", "<br>")
+
+// Markdown renders the value for a table cell: expressions in code spans and
+// multi-line values in a <pre> block. Strings longer than codeLength
+// characters are rendered as code spans too, unless codeLength is 0.
+func (d Default) Markdown(codeLength int) string {
+	switch {
+	case d.Kind == KindExpression:
+		return markdown.InlineCode(d.Value)
+	case d.Kind == KindMultiline:
+		return "<pre>" + preEscaper.Replace(strings.TrimRight(d.Value, "\n")) + "</pre>"
+	case d.Kind == KindString && codeLength > 0 && len(d.Value) > codeLength:
+		return markdown.InlineCode(d.Value)
+	}
+	return markdown.Escape(d.Value)
+}
+
+// YAML renders the value for a usage example, quoted when needed. Lines of
+// multi-line values are indented by indent spaces, two more than their key.
+func (d Default) YAML(indent int) string {
+	switch d.Kind {
+	case "":
+		return ""
+	case KindBool, KindNumber:
+		return d.Value
+	}
+
+	// Block scalars are encoded two spaces deeper than their key, matching
+	// the indentation indicator of values starting with spaces.
+	var b strings.Builder
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: d.Value}); err != nil {
+		return d.Value
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = strings.Repeat(" ", indent-2) + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,190 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDefault(t *testing.T) {
+	tests := []struct {
+		name             string
+		data             string
+		expectedKind     Kind
+		expectedMarkdown string
+		expectedYAML     string
+	}{
+		{
+			name:             "No default",
+			data:             "default:",
+			expectedKind:     "",
+			expectedMarkdown: "",
+			expectedYAML:     "",
+		},
+		{
+			name:             "String",
+			data:             "default: main",
+			expectedKind:     KindString,
+			expectedMarkdown: "main",
+			expectedYAML:     "main",
+		},
+		{
+			name:             "Quoted boolean",
+			data:             "default: 'true'",
+			expectedKind:     KindString,
+			expectedMarkdown: "true",
+			expectedYAML:     `"true"`,
+		},
+		{
+			name:             "Boolean",
+			data:             "default: true",
+			expectedKind:     KindBool,
+			expectedMarkdown: "true",
+			expectedYAML:     "true",
+		},
+		{
+			name:             "Number",
+			data:             "default: 3",
+			expectedKind:     KindNumber,
+			expectedMarkdown: "3",
+			expectedYAML:     "3",
+		},
+		{
+			name:             "Expression",
+			data:             "default: ${{ github.token }}",
+			expectedKind:     KindExpression,
+			expectedMarkdown: "`${{ github.token }}`",
+			expectedYAML:     "${{ github.token }}",
+		},
+		{
+			name:             "Special characters",
+			data:             "default: 'a: b | c'",
+			expectedKind:     KindString,
+			expectedMarkdown: "a: b | c",
+			expectedYAML:     "'a: b | c'",
+		},
+		{
+			name:             "Block scalar",
+			data:             "default: |\n  echo <hello>\n  exit 0\n",
+			expectedKind:     KindMultiline,
+			expectedMarkdown: "<pre>echo &lt;hello&gt;<br>exit 0</pre>",
+			expectedYAML:     "|\n      echo <hello>\n      exit 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v struct {
+				Default Default `yaml:"default"`
+			}
+			if err := yaml.Unmarshal([]byte(tt.data), &v); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if v.Default.Kind != tt.expectedKind {
+				t.Errorf(errorf, "Kind doesn't match", tt.expectedKind, v.Default.Kind)
+			}
+			if got := v.Default.Markdown(0); got != tt.expectedMarkdown {
+				t.Errorf(errorf, "Markdown doesn't match", tt.expectedMarkdown, got)
+			}
+			if got := v.Default.YAML(6); got != tt.expectedYAML {
+				t.Errorf(errorf, "YAML doesn't match", tt.expectedYAML, got)
+			}
+		})
+	}
+}
+
+func TestDefaultCodeLength(t *testing.T) {
+	tests := []struct {
+		name       string
+		given      Default
+		codeLength int
+		expected   string
+	}{
+		{name: "Disabled", given: Default{Value: "a long <value>", Kind: KindString}, codeLength: 0, expected: "a long &lt;value&gt;"},
+		{name: "Short", given: Default{Value: "short", Kind: KindString}, codeLength: 10, expected: "short"},
+		{name: "Long", given: Default{Value: "a long <value>", Kind: KindString}, codeLength: 10, expected: "`a long <value>`"},
+		{name: "Boolean", given: Default{Value: "false", Kind: KindBool}, codeLength: 3, expected: "false"},
+		{name: "Multiline", given: Default{Value: "a\nb", Kind: KindMultiline}, codeLength: 1, expected: "<pre>a<br>b</pre>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.given.Markdown(tt.codeLength); got != tt.expected {
+				t.Errorf(errorf, "Markdown doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInputMapToStringRoundTrip(t *testing.T) {
+	data := `
+bool:
+  default: false
+multiline:
+  default: |
+    line 1
+      line 2
+indented:
+  default: |2
+      line 1
+    line 2
+quoted:
+  default: 'yes'
+expression:
+  default: ${{ github.token }}
+`
+	var inputs InputMap
+	if err := yaml.Unmarshal([]byte(data), &inputs); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	var usage struct {
+		With map[string]any `yaml:"with"`
+	}
+	if err := yaml.Unmarshal([]byte(inputs.ToString(2)), &usage); err != nil {
+		t.Fatalf("Usage isn't valid YAML: %v\n%s", err, inputs.ToString(2))
+	}
+
+	expected := map[string]any{
+		"bool":       false,
+		"multiline":  "line 1\n  line 2\n",
+		"indented":   "  line 1\nline 2\n",
+		"quoted":     "yes",
+		"expression": "${{ github.token }}",
+	}
+	for name, value := range expected {
+		if usage.With[name] != value {
+			t.Errorf(errorf, "Value of "+name+" doesn't match", value, usage.With[name])
+		}
+	}
+}
+
+func TestInputJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{name: "Boolean", data: "default: true", expected: `{"default":{"value":"true","kind":"boolean"},"description":"","required":false}`},
+		{name: "Boolean as string", data: `default: "true"`, expected: `{"default":{"value":"true","kind":"string"},"description":"","required":false}`},
+		{name: "Empty string", data: `default: ""`, expected: `{"default":{"value":"","kind":"string"},"description":"","required":false}`},
+		{name: "No default", data: "description: d", expected: `{"description":"d","required":false}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var input Input
+			if err := yaml.Unmarshal([]byte(tt.data), &input); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			b, err := json.Marshal(input)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if string(b) != tt.expected {
+				t.Errorf(errorf, "JSON doesn't match", tt.expected, string(b))
+			}
+		})
+	}
+}
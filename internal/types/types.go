@@ -1,30 +1,201 @@
 package types
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
+	"time"
+
+	"github.com/nu12/action-docs/internal/markdown"
+	"gopkg.in/yaml.v3"
 )
 
+// Position is the location of a key in its source file.
+type Position struct {
+	Line   int
+	Column int
+}
+
 type Input struct {
-	Default     string `yaml:"default,omitempty"`
-	Description string `yaml:"description,omitempty"`
-	Required    bool   `yaml:"required,omitempty"`
-	Type        string `yaml:"type,omitempty"`
+	Default     Default  `yaml:"default,omitempty" json:"default"`
+	Description string   `yaml:"description,omitempty" json:"description"`
+	Required    bool     `yaml:"required,omitempty" json:"required"`
+	Type        string   `yaml:"type,omitempty" json:"type,omitempty"`
+	Options     []string `yaml:"options,omitempty" json:"options,omitempty"`
+	Comment     string   `yaml:"-" json:"comment,omitempty"`
+	Position    Position `yaml:"-" json:"-"`
+}
+
+// MarshalJSON omits the default of inputs that have none.
+func (i Input) MarshalJSON() ([]byte, error) {
+	type plain Input
+	var d *Default
+	if !i.Default.IsZero() {
+		d = &i.Default
+	}
+	return json.Marshal(struct {
+		Default *Default `json:"default,omitempty"`
+		plain
+	}{d, plain(i)})
+}
+
+// Allowed describes the values accepted by a workflow_dispatch input of the
+// given type, empty for free-form inputs.
+func (i Input) Allowed() string {
+	switch i.Type {
+	case "choice":
+		values := make([]string, len(i.Options))
+		for n, option := range i.Options {
+			values[n] = markdown.InlineCode(option)
+		}
+		return strings.Join(values, ", ")
+	case "boolean":
+		return "`true`, `false`"
+	case "number":
+		return "Any number"
+	case "environment":
+		return "Any environment of the repository"
+	}
+	return ""
 }
 
 type Output struct {
-	Description string `yaml:"description,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description"`
+	Comment     string   `yaml:"-" json:"comment,omitempty"`
+	Position    Position `yaml:"-" json:"-"`
 }
 
 type Secret struct {
-	Required bool `yaml:"required,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool     `yaml:"required,omitempty" json:"required"`
+	Comment     string   `yaml:"-" json:"comment,omitempty"`
+	Position    Position `yaml:"-" json:"-"`
 }
 
 type InputMap map[string]Input
 type OutputMap map[string]Output
 type SecretMap map[string]Secret
 
+func (im *InputMap) UnmarshalYAML(node *yaml.Node) error {
+	m, err := decodeMap[Input](node, func(i *Input, p Position, c string) { i.Position, i.Comment = p, c })
+	*im = m
+	return err
+}
+
+func (om *OutputMap) UnmarshalYAML(node *yaml.Node) error {
+	m, err := decodeMap[Output](node, func(o *Output, p Position, c string) { o.Position, o.Comment = p, c })
+	*om = m
+	return err
+}
+
+func (sm *SecretMap) UnmarshalYAML(node *yaml.Node) error {
+	m, err := decodeMap[Secret](node, func(s *Secret, p Position, c string) { s.Position, s.Comment = p, c })
+	*sm = m
+	return err
+}
+
+// decodeMap decodes every item of a mapping node, recording the position and
+// the comments of its key. Items that fail to decode are kept with their
+// position.
+func decodeMap[M Input | Output | Secret](node *yaml.Node, set func(*M, Position, string)) (map[string]M, error) {
+	result := map[string]M{}
+	if node.Kind != yaml.MappingNode {
+		return result, node.Decode(&map[string]M{})
+	}
+
+	var errs []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		var item M
+		if value.Kind != yaml.ScalarNode || value.Tag != "!!null" {
+			if err := value.Decode(&item); err != nil {
+				var typeError *yaml.TypeError
+				if !errors.As(err, &typeError) {
+					return result, err
+				}
+				errs = append(errs, typeError.Errors...)
+			}
+		}
+		set(&item, Position{Line: key.Line, Column: key.Column}, Comment(key.HeadComment, key.LineComment, value.LineComment))
+		result[key.Value] = item
+	}
+	if len(errs) > 0 {
+		return result, &yaml.TypeError{Errors: errs}
+	}
+	return result, nil
+}
+
+// Comment returns the text of YAML comments, without the comment markers.
+// Editor directives such as yaml-language-server are left out.
+func Comment(comments ...string) string {
+	var lines []string
+	for _, comment := range comments {
+		for _, line := range strings.Split(comment, "\n") {
+			line = strings.TrimSpace(line)
+			if !strings.HasPrefix(line, "#") {
+				continue
+			}
+			line = strings.TrimSpace(strings.TrimLeft(line, "#"))
+			if strings.HasPrefix(line, "yaml-language-server:") {
+				continue
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// FileComment returns the comment at the top of a YAML document, right above
+// its first key. A header separated from the first key by an empty line, such
+// as a copyright notice, is not documentation and is left out.
+func FileComment(root *yaml.Node) string {
+	if root.Kind != yaml.DocumentNode {
+		return ""
+	}
+	if len(root.Content) > 0 && root.Content[0].Kind == yaml.MappingNode && len(root.Content[0].Content) > 0 {
+		return Comment(root.Content[0].Content[0].HeadComment)
+	}
+	return ""
+}
+
+// What documents items, see Options.Describe.
+const (
+	DescribeDescription = "description"
+	DescribeComment     = "comment"
+	DescribeBoth        = "both"
+)
+
+// Options change how items are documented.
+type Options struct {
+	// DescribeWith is what documents items: DescribeDescription (default),
+	// DescribeComment or DescribeBoth.
+	DescribeWith string
+	// NextRuns is the number of upcoming runs listed for each schedule,
+	// computed from Now.
+	NextRuns int
+	Now      time.Time
+	// NewLine replaces the line breaks of table cells, <br> when empty.
+	NewLine string
+	// CodeDefaults renders default values longer than this many characters
+	// as code spans, 0 disables it.
+	CodeDefaults int
+}
+
+// Describe returns the documentation of an item. Comments are left out by
+// default. With DescribeComment the comment replaces the description, and with
+// DescribeBoth it extends the description as a new paragraph.
+func (o Options) Describe(description, comment string) string {
+	switch {
+	case comment == "" || o.DescribeWith != DescribeComment && o.DescribeWith != DescribeBoth:
+		return description
+	case description == "", o.DescribeWith == DescribeComment:
+		return comment
+	}
+	return description + "\n\n" + comment
+}
+
 func (left *InputMap) Equals(right *InputMap) bool {
 	return left.ToString(2) == right.ToString(2)
 }
@@ -81,14 +252,8 @@ func (sm *SecretMap) Sort() {
 }
 
 func Sort[M Input | Output | Secret](m map[string]M) map[string]M {
-	keys := make([]string, 0, len(m))
-	for k := range m {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
 	sorted := map[string]M{}
-	for _, k := range keys {
+	for _, k := range keys(m) {
 		sorted[k] = m[k]
 	}
 	return sorted
@@ -104,8 +269,65 @@ func (im *InputMap) ToString(spacing int) string {
 
 	var result = []string{}
 	for name, item := range *im {
-		result = append(result, fmt.Sprintf("%s%s: %s\n", strings.Repeat(" ", spacing), name, item.Default))
+		result = append(result, fmt.Sprintf("%s%s: %s\n", strings.Repeat(" ", spacing), name, item.Default.YAML(spacing+2)))
 	}
 	sort.Strings(result)
 	return fmt.Sprintf("%swith:\n%s", strings.Repeat(" ", spacing-2), strings.Join(result, ""))
 }
+
+type NamedInput struct {
+	Name string
+	Input
+}
+
+type NamedOutput struct {
+	Name string
+	Output
+}
+
+type NamedSecret struct {
+	Name string
+	Secret
+}
+
+func (im *InputMap) List() []NamedInput {
+	result := []NamedInput{}
+	if im == nil {
+		return result
+	}
+	for _, name := range keys(*im) {
+		result = append(result, NamedInput{name, (*im)[name]})
+	}
+	return result
+}
+
+func (om *OutputMap) List() []NamedOutput {
+	result := []NamedOutput{}
+	if om == nil {
+		return result
+	}
+	for _, name := range keys(*om) {
+		result = append(result, NamedOutput{name, (*om)[name]})
+	}
+	return result
+}
+
+func (sm *SecretMap) List() []NamedSecret {
+	result := []NamedSecret{}
+	if sm == nil {
+		return result
+	}
+	for _, name := range keys(*sm) {
+		result = append(result, NamedSecret{name, (*sm)[name]})
+	}
+	return result
+}
+
+func keys[M Input | Output | Secret](m map[string]M) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,6 +1,10 @@
 package types
 
-import "testing"
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
 
 const errorf = "Error: %v. \nExpected: %v \nGot: %v"
 
@@ -51,8 +55,8 @@ func TestInputMapToString(t *testing.T) {
 
 func TestEquals4InputMap(t *testing.T) {
 	left := &InputMap{
-		"input1": {Description: "desc1", Required: true, Default: "default1"},
-		"input2": {Description: "desc2", Required: false, Default: "default2"},
+		"input1": {Description: "desc1", Required: true, Default: Default{Value: "default1", Kind: KindString}},
+		"input2": {Description: "desc2", Required: false, Default: Default{Value: "default2", Kind: KindString}},
 	}
 	tests := []struct {
 		name     string
@@ -62,24 +66,24 @@ func TestEquals4InputMap(t *testing.T) {
 		{
 			name: "Identical maps",
 			right: &InputMap{
-				"input1": {Description: "desc1", Required: true, Default: "default1"},
-				"input2": {Description: "desc2", Required: false, Default: "default2"},
+				"input1": {Description: "desc1", Required: true, Default: Default{Value: "default1", Kind: KindString}},
+				"input2": {Description: "desc2", Required: false, Default: Default{Value: "default2", Kind: KindString}},
 			},
 			expected: true,
 		},
 		{
 			name: "Unordered maps",
 			right: &InputMap{
-				"input2": {Description: "desc2", Required: false, Default: "default2"},
-				"input1": {Description: "desc1", Required: true, Default: "default1"},
+				"input2": {Description: "desc2", Required: false, Default: Default{Value: "default2", Kind: KindString}},
+				"input1": {Description: "desc1", Required: true, Default: Default{Value: "default1", Kind: KindString}},
 			},
 			expected: true,
 		},
 		{
 			name: "Different keys",
 			right: &InputMap{
-				"input1": {Description: "desc1", Required: true, Default: "default1"},
-				"input3": {Description: "desc2", Required: false, Default: "default2"},
+				"input1": {Description: "desc1", Required: true, Default: Default{Value: "default1", Kind: KindString}},
+				"input3": {Description: "desc2", Required: false, Default: Default{Value: "default2", Kind: KindString}},
 			},
 
 			expected: false,
@@ -87,8 +91,8 @@ func TestEquals4InputMap(t *testing.T) {
 		{
 			name: "Different default values",
 			right: &InputMap{
-				"input1": {Description: "desc1", Required: true, Default: "default1"},
-				"input2": {Description: "desc2", Required: false, Default: "default3"},
+				"input1": {Description: "desc1", Required: true, Default: Default{Value: "default1", Kind: KindString}},
+				"input2": {Description: "desc2", Required: false, Default: Default{Value: "default3", Kind: KindString}},
 			},
 
 			expected: false,
@@ -96,7 +100,7 @@ func TestEquals4InputMap(t *testing.T) {
 		{
 			name: "Different size",
 			right: &InputMap{
-				"input1": {Description: "desc1", Required: true, Default: "default1"},
+				"input1": {Description: "desc1", Required: true, Default: Default{Value: "default1", Kind: KindString}},
 			},
 			expected: false,
 		},
@@ -238,3 +242,207 @@ func TestEquals4SecretMap(t *testing.T) {
 	}
 
 }
+
+func TestInputMapList(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    *InputMap
+		expected []string
+	}{
+		{
+			name: "Sorted by name",
+			given: &InputMap{
+				"in2": {Description: "Input2"},
+				"in1": {Description: "Input1"},
+				"in3": {Description: "Input3"},
+			},
+			expected: []string{"in1", "in2", "in3"},
+		},
+		{
+			name:     "Nil inputs",
+			given:    nil,
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.given.List()
+			if len(got) != len(tt.expected) {
+				t.Fatalf(errorf, "List size mismatch", len(tt.expected), len(got))
+			}
+			for i, name := range tt.expected {
+				if got[i].Name != name {
+					t.Errorf(errorf, "List order doesn't match", name, got[i].Name)
+				}
+				if got[i].Description != (*tt.given)[name].Description {
+					t.Errorf(errorf, "Description doesn't match", (*tt.given)[name].Description, got[i].Description)
+				}
+			}
+		})
+	}
+}
+
+func TestUnmarshalPositions(t *testing.T) {
+	data := `
+inputs:
+  in1:
+    description: 'Input1'
+  in2:
+outputs:
+    out1:
+      description: 'Output1'
+secrets:
+  sec1:
+    required: true
+`
+	var v struct {
+		Inputs  InputMap  `yaml:"inputs"`
+		Outputs OutputMap `yaml:"outputs"`
+		Secrets SecretMap `yaml:"secrets"`
+	}
+	if err := yaml.Unmarshal([]byte(data), &v); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		got      Position
+		expected Position
+	}{
+		{name: "Input", got: v.Inputs["in1"].Position, expected: Position{Line: 3, Column: 3}},
+		{name: "Empty input", got: v.Inputs["in2"].Position, expected: Position{Line: 5, Column: 3}},
+		{name: "Output", got: v.Outputs["out1"].Position, expected: Position{Line: 7, Column: 5}},
+		{name: "Secret", got: v.Secrets["sec1"].Position, expected: Position{Line: 10, Column: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf(errorf, "Position doesn't match", tt.expected, tt.got)
+			}
+		})
+	}
+
+	if v.Inputs["in1"].Description != "Input1" {
+		t.Errorf(errorf, "Description doesn't match", "Input1", v.Inputs["in1"].Description)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	data := `
+inputs:
+  in1: 'not a map'
+  in2:
+    description: 'Input2'
+`
+	var v struct {
+		Inputs InputMap `yaml:"inputs"`
+	}
+	err := yaml.Unmarshal([]byte(data), &v)
+	if err == nil {
+		t.Fatalf("error: %s", "Invalid input should fail")
+	}
+	if v.Inputs["in2"].Description != "Input2" {
+		t.Errorf(errorf, "Valid inputs should be kept", "Input2", v.Inputs["in2"].Description)
+	}
+}
+
+func TestUnmarshalComments(t *testing.T) {
+	data := `# Copyright 2024 Acme Corp. All rights reserved.
+
+# yaml-language-server: $schema=action.json
+# The file comment
+name: 'Action'
+inputs:
+  # Head comment
+  # on two lines
+  in1:
+    description: 'Input1'
+  in2: # Line comment
+  in3:
+outputs:
+  out1: {description: 'Output1'} # Value comment
+secrets:
+  sec1:
+    required: true
+`
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(data), &root); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	var v struct {
+		Inputs  InputMap  `yaml:"inputs"`
+		Outputs OutputMap `yaml:"outputs"`
+		Secrets SecretMap `yaml:"secrets"`
+	}
+	if err := root.Decode(&v); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{name: "File", got: FileComment(&root), expected: "The file comment"},
+		{name: "Head comment", got: v.Inputs["in1"].Comment, expected: "Head comment\non two lines"},
+		{name: "Line comment", got: v.Inputs["in2"].Comment, expected: "Line comment"},
+		{name: "No comment", got: v.Inputs["in3"].Comment, expected: ""},
+		{name: "Value comment", got: v.Outputs["out1"].Comment, expected: "Value comment"},
+		{name: "Secret without comment", got: v.Secrets["sec1"].Comment, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf(errorf, "Comment doesn't match", tt.expected, tt.got)
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     Options
+		description string
+		comment     string
+		expected    string
+	}{
+		{name: "Description by default", description: "desc", comment: "comment", expected: "desc"},
+		{name: "Comment left out by default", comment: "comment", expected: ""},
+		{name: "Description", options: Options{DescribeWith: DescribeDescription}, description: "desc", comment: "comment", expected: "desc"},
+		{name: "Comment", options: Options{DescribeWith: DescribeComment}, description: "desc", comment: "comment", expected: "comment"},
+		{name: "Comment without comment", options: Options{DescribeWith: DescribeComment}, description: "desc", expected: "desc"},
+		{name: "Both", options: Options{DescribeWith: DescribeBoth}, description: "desc", comment: "comment", expected: "desc\n\ncomment"},
+		{name: "Both with comment only", options: Options{DescribeWith: DescribeBoth}, comment: "comment", expected: "comment"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.options.Describe(tt.description, tt.comment); got != tt.expected {
+				t.Errorf(errorf, "Description doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestInputAllowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    Input
+		expected string
+	}{
+		{name: "Choice", given: Input{Type: "choice", Options: []string{"one", "a|b"}}, expected: "`one`, `a|b`"},
+		{name: "Choice without options", given: Input{Type: "choice"}, expected: ""},
+		{name: "Boolean", given: Input{Type: "boolean"}, expected: "`true`, `false`"},
+		{name: "Number", given: Input{Type: "number"}, expected: "Any number"},
+		{name: "Environment", given: Input{Type: "environment"}, expected: "Any environment of the repository"},
+		{name: "String", given: Input{Type: "string"}, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.given.Allowed(); got != tt.expected {
+				t.Errorf(errorf, "Allowed values don't match", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package version
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+// Set at build time, e.g.:
+//
+//	go build -ldflags "-X github.com/nu12/action-docs/internal/version.Version=v1.2.3"
+//
+// Unset values are read from the build information embedded by the Go
+// toolchain, as for `go install` builds.
+var (
+	Version = ""
+	Commit  = ""
+	Date    = ""
+)
+
+// Dev is the version of builds without a release tag.
+const Dev = "dev"
+
+// Info describes the build of the running binary.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"goVersion"`
+	Platform  string `json:"platform"`
+	Module    string `json:"module,omitempty"`
+	Sum       string `json:"sum,omitempty"`
+}
+
+// Get returns the build information of the running binary.
+func Get() Info {
+	bi, _ := debug.ReadBuildInfo()
+	return get(bi)
+}
+
+func get(bi *debug.BuildInfo) Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		Date:      Date,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if bi != nil {
+		info.GoVersion = bi.GoVersion
+		info.Module = bi.Main.Path
+		info.Sum = bi.Main.Sum
+		if info.Version == "" && bi.Main.Version != "(devel)" {
+			info.Version = bi.Main.Version
+		}
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.Commit == "" {
+					info.Commit = setting.Value
+				}
+			case "vcs.time":
+				if info.Date == "" {
+					info.Date = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+	if info.Version == "" {
+		info.Version = Dev
+	}
+	return info
+}
+
+// String returns the information as a list of fields, without the unknown
+// ones.
+func (i Info) String() string {
+	commit := i.Commit
+	if commit != "" && i.Modified {
+		commit += " (modified)"
+	}
+	module := i.Module
+	if module != "" && i.Sum != "" {
+		module += " " + i.Sum
+	}
+
+	var b strings.Builder
+	for _, field := range []struct{ name, value string }{
+		{"Version", i.Version},
+		{"Commit", commit},
+		{"Built", i.Date},
+		{"Go version", i.GoVersion},
+		{"Platform", i.Platform},
+		{"Module", module},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(&b, "%-11s %s\n", field.name+":", field.value)
+		}
+	}
+	return b.String()
+}
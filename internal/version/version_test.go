@@ -0,0 +1,77 @@
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+)
+
+const errorf = "Error: %v. \nExpected: %v \nGot: %v"
+
+func TestGet(t *testing.T) {
+	installed := &debug.BuildInfo{
+		GoVersion: "go1.23.6",
+		Main:      debug.Module{Path: "github.com/nu12/action-docs", Version: "v1.2.3", Sum: "h1:abc="},
+	}
+	local := &debug.BuildInfo{
+		GoVersion: "go1.23.6",
+		Main:      debug.Module{Path: "github.com/nu12/action-docs", Version: "(devel)"},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef"},
+			{Key: "vcs.time", Value: "2024-03-01T10:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+	platform := runtime.GOOS + "/" + runtime.GOARCH
+
+	tests := []struct {
+		name     string
+		given    *debug.BuildInfo
+		ldflags  [3]string
+		expected Info
+	}{
+		{
+			name:     "No build information",
+			expected: Info{Version: Dev, GoVersion: runtime.Version(), Platform: platform},
+		},
+		{
+			name:     "Go install",
+			given:    installed,
+			expected: Info{Version: "v1.2.3", GoVersion: "go1.23.6", Platform: platform, Module: "github.com/nu12/action-docs", Sum: "h1:abc="},
+		},
+		{
+			name:     "Local build",
+			given:    local,
+			expected: Info{Version: Dev, Commit: "0123456789abcdef", Date: "2024-03-01T10:00:00Z", Modified: true, GoVersion: "go1.23.6", Platform: platform, Module: "github.com/nu12/action-docs"},
+		},
+		{
+			name:     "Release build",
+			given:    local,
+			ldflags:  [3]string{"v2.0.0", "fedcba", "2024-04-01T00:00:00Z"},
+			expected: Info{Version: "v2.0.0", Commit: "fedcba", Date: "2024-04-01T00:00:00Z", Modified: true, GoVersion: "go1.23.6", Platform: platform, Module: "github.com/nu12/action-docs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Version, Commit, Date = tt.ldflags[0], tt.ldflags[1], tt.ldflags[2]
+			t.Cleanup(func() { Version, Commit, Date = "", "", "" })
+
+			if got := get(tt.given); got != tt.expected {
+				t.Errorf(errorf, "Info doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	info := Info{Version: "v1.2.3", Commit: "abc", Modified: true, GoVersion: "go1.23.6", Platform: "linux/amd64", Module: "github.com/nu12/action-docs", Sum: "h1:abc="}
+	expected := "Version:    v1.2.3\n" +
+		"Commit:     abc (modified)\n" +
+		"Go version: go1.23.6\n" +
+		"Platform:   linux/amd64\n" +
+		"Module:     github.com/nu12/action-docs h1:abc=\n"
+	if got := info.String(); got != expected {
+		t.Errorf(errorf, "String doesn't match", expected, got)
+	}
+}
@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nu12/action-docs/internal/types"
+)
+
+// IsDispatchable reports whether the workflow can be run manually.
+func (w *Workflow) IsDispatchable() bool {
+	return w.On.WorkflowDispatch != nil
+}
+
+// DispatchUsage returns a GitHub CLI command running the workflow on the ref of
+// the repository, or the default branch, empty for workflows that can't be run
+// manually.
+func (w *Workflow) DispatchUsage() string {
+	if !w.IsDispatchable() {
+		return ""
+	}
+	command := "gh workflow run " + filepath.Base(w.Filename)
+	if w.Repo.Slug != "" {
+		command += " --repo " + w.Repo.Slug
+	}
+	if w.Repo.Ref != "" {
+		command += " --ref " + shellQuote(w.Repo.Ref)
+	}
+	lines := []string{command}
+	for _, input := range w.getDispatchInputs().List() {
+		lines = append(lines, "  -f "+shellQuote(input.Name+"="+exampleValue(input)))
+	}
+	return strings.Join(lines, " \\\n")
+}
+
+// DispatchRequest returns a REST API request running the workflow on the ref of
+// the repository, or main, empty for workflows that can't be run manually.
+func (w *Workflow) DispatchRequest() string {
+	if !w.IsDispatchable() {
+		return ""
+	}
+	payload := struct {
+		Ref    string            `json:"ref"`
+		Inputs map[string]string `json:"inputs,omitempty"`
+	}{Ref: w.Repo.Ref, Inputs: map[string]string{}}
+	if payload.Ref == "" {
+		payload.Ref = "main"
+	}
+	for _, input := range w.getDispatchInputs().List() {
+		payload.Inputs[input.Name] = exampleValue(input)
+	}
+	b, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return ""
+	}
+	slug := w.Repo.Slug
+	if slug == "" {
+		slug = "{owner}/{repo}"
+	}
+	return fmt.Sprintf("POST /repos/%s/actions/workflows/%s/dispatches\n\n%s", slug, filepath.Base(w.Filename), b)
+}
+
+// exampleValue returns the default of an input, its first option or a
+// placeholder.
+func exampleValue(input types.NamedInput) string {
+	switch {
+	case input.Default.Value != "":
+		return input.Default.Value
+	case len(input.Options) > 0:
+		return input.Options[0]
+	}
+	return "<" + input.Name + ">"
+}
+
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9_./:=@%+,-]+$`)
+
+func shellQuote(s string) string {
+	if shellSafe.MatchString(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
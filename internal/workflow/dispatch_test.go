@@ -0,0 +1,108 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/nu12/action-docs/internal/repo"
+	"github.com/nu12/action-docs/internal/types"
+)
+
+func TestDispatchUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    *Workflow
+		expected string
+	}{
+		{
+			name:     "Not dispatchable",
+			given:    &Workflow{Filename: ".github/workflows/ci.yml"},
+			expected: "",
+		},
+		{
+			name: "Without inputs",
+			given: &Workflow{
+				Filename: ".github/workflows/ci.yml",
+				On:       On{WorkflowDispatch: &WorkflowDispatch{}},
+			},
+			expected: "gh workflow run ci.yml",
+		},
+		{
+			name: "Inputs",
+			given: &Workflow{
+				Filename: ".github/workflows/ci.yml",
+				On: On{WorkflowDispatch: &WorkflowDispatch{Inputs: &types.InputMap{
+					"env":     {Type: "choice", Options: []string{"staging", "production"}},
+					"message": {Default: types.Default{Value: "it's done", Kind: types.KindString}},
+					"version": {},
+				}}},
+			},
+			expected: "gh workflow run ci.yml \\\n  -f env=staging \\\n  -f 'message=it'\\''s done' \\\n  -f 'version=<version>'",
+		},
+		{
+			name: "Repository",
+			given: &Workflow{
+				Filename: ".github/workflows/ci.yml",
+				On:       On{WorkflowDispatch: &WorkflowDispatch{}},
+				Repo:     repo.Info{Slug: "acme/tools"},
+			},
+			expected: "gh workflow run ci.yml --repo acme/tools",
+		},
+		{
+			name: "Ref",
+			given: &Workflow{
+				Filename: ".github/workflows/ci.yml",
+				On:       On{WorkflowDispatch: &WorkflowDispatch{}},
+				Repo:     repo.Info{Slug: "acme/tools", Ref: "develop"},
+			},
+			expected: "gh workflow run ci.yml --repo acme/tools --ref develop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.given.DispatchUsage(); got != tt.expected {
+				t.Errorf(errorf, "Usage doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDispatchRequest(t *testing.T) {
+	inputs := &types.InputMap{"env": {Type: "choice", Options: []string{"staging", "production"}}}
+	tests := []struct {
+		name     string
+		given    *Workflow
+		expected string
+	}{
+		{
+			name:     "Not dispatchable",
+			given:    &Workflow{Filename: ".github/workflows/ci.yml"},
+			expected: "",
+		},
+		{
+			name: "Unknown repository",
+			given: &Workflow{
+				Filename: ".github/workflows/ci.yml",
+				On:       On{WorkflowDispatch: &WorkflowDispatch{Inputs: inputs}},
+			},
+			expected: "POST /repos/{owner}/{repo}/actions/workflows/ci.yml/dispatches\n\n{\n  \"ref\": \"main\",\n  \"inputs\": {\n    \"env\": \"staging\"\n  }\n}",
+		},
+		{
+			name: "Repository",
+			given: &Workflow{
+				Filename: ".github/workflows/ci.yml",
+				On:       On{WorkflowDispatch: &WorkflowDispatch{}},
+				Repo:     repo.Info{Slug: "acme/tools", Ref: "develop"},
+			},
+			expected: "POST /repos/acme/tools/actions/workflows/ci.yml/dispatches\n\n{\n  \"ref\": \"develop\"\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.given.DispatchRequest(); got != tt.expected {
+				t.Errorf(errorf, "Request doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nu12/action-docs/internal/markdown"
+	"github.com/nu12/action-docs/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+type Job struct {
+	ID             string         `yaml:"-" json:"id"`
+	Name           string         `yaml:"name" json:"name,omitempty"`
+	RunsOn         *RunsOn        `yaml:"runs-on" json:"runs-on,omitempty"`
+	Needs          StringList     `yaml:"needs" json:"needs,omitempty"`
+	If             string         `yaml:"if" json:"if,omitempty"`
+	Environment    *Environment   `yaml:"environment" json:"environment,omitempty"`
+	TimeoutMinutes string         `yaml:"timeout-minutes" json:"timeout-minutes,omitempty"`
+	Uses           string         `yaml:"uses" json:"uses,omitempty"`
+	Position       types.Position `yaml:"-" json:"-"`
+}
+
+// Jobs keeps the jobs in the order of the file.
+type Jobs []Job
+
+func (j *Jobs) UnmarshalYAML(node *yaml.Node) error {
+	*j = Jobs{}
+	if node.Kind != yaml.MappingNode {
+		return node.Decode(&map[string]Job{})
+	}
+
+	var errs []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		var job Job
+		if err := value.Decode(&job); err != nil {
+			var typeError *yaml.TypeError
+			if !errors.As(err, &typeError) {
+				return err
+			}
+			errs = append(errs, typeError.Errors...)
+		}
+		job.ID = key.Value
+		job.Position = types.Position{Line: key.Line, Column: key.Column}
+		*j = append(*j, job)
+	}
+	if len(errs) > 0 {
+		return &yaml.TypeError{Errors: errs}
+	}
+	return nil
+}
+
+// RunsOn is the runner of a job: a label, a list of labels or a runner group.
+type RunsOn struct {
+	Group  string     `yaml:"group" json:"group,omitempty"`
+	Labels StringList `yaml:"labels" json:"labels,omitempty"`
+}
+
+func (r *RunsOn) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		type plain RunsOn
+		return node.Decode((*plain)(r))
+	}
+	return node.Decode(&r.Labels)
+}
+
+func (r *RunsOn) String() string {
+	if r == nil {
+		return ""
+	}
+	labels := make([]string, len(r.Labels))
+	for i, label := range r.Labels {
+		labels[i] = markdown.InlineCode(label)
+	}
+	s := strings.Join(labels, ", ")
+	if r.Group != "" {
+		group := "group " + markdown.InlineCode(r.Group)
+		if s != "" {
+			group += ": " + s
+		}
+		s = group
+	}
+	return s
+}
+
+// Environment is the deployment environment of a job, written as its name or
+// as a name and a URL.
+type Environment struct {
+	Name string `yaml:"name" json:"name,omitempty"`
+	URL  string `yaml:"url" json:"url,omitempty"`
+}
+
+func (e *Environment) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		type plain Environment
+		return node.Decode((*plain)(e))
+	}
+	return node.Decode(&e.Name)
+}
+
+func (w *Workflow) jobsSection() *markdown.Section {
+	section := markdown.NewSection("jobs")
+	if len(w.Jobs) == 0 {
+		return section
+	}
+
+	table := markdown.Table{
+		Header:   markdown.Header{"Job", "Name", "Runs on", "Needs", "If", "Environment", "Timeout (minutes)", "Uses"},
+		Verbatim: []int{0, 2, 3, 4, 5, 7},
+		NewLine:  w.Options.NewLine,
+	}
+	for _, job := range w.Jobs {
+		environment := ""
+		if job.Environment != nil {
+			environment = markdown.InlineCode(job.Environment.Name)
+		}
+		needs := make([]string, len(job.Needs))
+		for i, need := range job.Needs {
+			needs[i] = markdown.InlineCode(need)
+		}
+		table.AddRow(markdown.Row{
+			markdown.InlineCode(job.ID),
+			job.Name,
+			job.RunsOn.String(),
+			strings.Join(needs, ", "),
+			markdown.InlineCode(job.If),
+			environment,
+			job.TimeoutMinutes,
+			markdown.InlineCode(job.Uses),
+		})
+	}
+	section.Add(markdown.H3("Jobs"))
+	if len(w.Jobs) > 1 {
+		section.Add(markdown.Mermaid(w.Graph()))
+	}
+	section.Add(&table)
+	return section
+}
+
+// Graph returns a Mermaid flowchart of the jobs and their needs. Jobs calling
+// a reusable workflow are drawn as subroutines and conditional jobs as
+// hexagons, labelled with what they use and their condition.
+func (w *Workflow) Graph() string {
+	ids := map[string]string{}
+	for i, job := range w.Jobs {
+		ids[job.ID] = fmt.Sprintf("job%d", i)
+	}
+
+	lines := []string{"flowchart LR"}
+	for _, job := range w.Jobs {
+		label := job.ID
+		if job.Name != "" {
+			label = job.Name
+		}
+		if job.Uses != "" {
+			label += "<br>uses: " + job.Uses
+		}
+		if job.If != "" {
+			label += "<br>if: " + job.If
+		}
+		label = `"` + mermaidEscaper.Replace(label) + `"`
+
+		switch {
+		case job.Uses != "":
+			label = "[[" + label + "]]"
+		case job.If != "":
+			label = "{{" + label + "}}"
+		default:
+			label = "[" + label + "]"
+		}
+		lines = append(lines, "  "+ids[job.ID]+label)
+	}
+	for _, job := range w.Jobs {
+		for _, need := range job.Needs {
+			if id, ok := ids[need]; ok {
+				lines = append(lines, "  "+id+" --> "+ids[job.ID])
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// mermaidEscaper escapes quoted labels, keeping the line breaks added to them.
+var mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "<br>", "<br>", "<", "#lt;", ">", "#gt;")
@@ -0,0 +1,108 @@
+package workflow
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestJobs(t *testing.T) {
+	data := `name: 'Deploy'
+on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+    - run: make test
+  build:
+    name: Build image
+    runs-on: [self-hosted, linux]
+    needs: test
+    timeout-minutes: 30
+  deploy:
+    runs-on:
+      group: production
+      labels: [linux]
+    needs: [test, build]
+    if: github.ref == 'refs/heads/main' || inputs.force
+    environment:
+      name: production
+      url: https://example.com
+  notify:
+    uses: ./.github/workflows/notify.yml
+`
+	file := t.TempDir() + "/deploy.yml"
+	if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	w, diagnostics := Parse(file)
+	if len(diagnostics) > 0 {
+		t.Fatalf("error: %v", diagnostics)
+	}
+
+	ids := []string{}
+	for _, job := range w.Jobs {
+		ids = append(ids, job.ID)
+	}
+	if strings.Join(ids, ",") != "test,build,deploy,notify" {
+		t.Errorf(errorf, "Jobs should keep the order of the file", "test,build,deploy,notify", ids)
+	}
+	if w.Jobs[2].Position.Line != 13 {
+		t.Errorf(errorf, "Position doesn't match", 13, w.Jobs[2].Position.Line)
+	}
+
+	expected := "### Jobs\n\n" +
+		"```mermaid\n" +
+		"flowchart LR\n" +
+		"  job0[\"test\"]\n" +
+		"  job1[\"Build image\"]\n" +
+		"  job2{{\"deploy<br>if: github.ref == 'refs/heads/main' || inputs.force\"}}\n" +
+		"  job3[[\"notify<br>uses: ./.github/workflows/notify.yml\"]]\n" +
+		"  job0 --> job1\n" +
+		"  job0 --> job2\n" +
+		"  job1 --> job2\n" +
+		"```\n\n" +
+		"|Job|Name|Runs on|Needs|If|Environment|Timeout (minutes)|Uses|\n" +
+		"|---|---|---|---|---|---|---|---|\n" +
+		"|`test`||`ubuntu-latest`||||||\n" +
+		"|`build`|Build image|`self-hosted`, `linux`|`test`|||30||\n" +
+		"|`deploy`||group `production`: `linux`|`test`, `build`|`github.ref == 'refs/heads/main' \\|\\| inputs.force`|`production`|||\n" +
+		"|`notify`|||||||`./.github/workflows/notify.yml`|\n\n"
+	if got := w.jobsSection().String(); got != expected {
+		t.Errorf(errorf, "Jobs section doesn't match", expected, got)
+	}
+}
+
+func TestGraph(t *testing.T) {
+	tests := []struct {
+		name     string
+		given    Jobs
+		expected string
+	}{
+		{
+			name:     "No jobs",
+			given:    Jobs{},
+			expected: "flowchart LR",
+		},
+		{
+			name: "Escaped labels",
+			given: Jobs{
+				{ID: "end", Name: `Say "hi" <now>`},
+				{ID: "next", Needs: StringList{"end", "missing"}, If: "${{ always() }}", Uses: "org/repo/.github/workflows/a.yml@v1"},
+			},
+			expected: "flowchart LR\n" +
+				"  job0[\"Say #quot;hi#quot; #lt;now#gt;\"]\n" +
+				"  job1[[\"next<br>uses: org/repo/.github/workflows/a.yml@v1<br>if: ${{ always() }}\"]]\n" +
+				"  job0 --> job1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Workflow{Jobs: tt.given}
+			if got := w.Graph(); got != tt.expected {
+				t.Errorf(errorf, "Graph doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
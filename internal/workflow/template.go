@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	_ "embed"
+	"text/template"
+
+	"github.com/nu12/action-docs/internal/markdown"
+	"github.com/nu12/action-docs/internal/render"
+	"github.com/nu12/action-docs/internal/repo"
+	"github.com/nu12/action-docs/internal/types"
+)
+
+//go:embed workflows.md.tmpl
+var Template string
+
+// Data is the model of a single workflow given to workflows templates.
+type Data struct {
+	Workflow           *Workflow
+	Name               string
+	Title              string
+	Description        string
+	Comment            string
+	File               string
+	Path               string
+	IsReusableWorkflow bool
+	Triggers           []string
+	Usage              string
+	Inputs             []types.NamedInput
+	Outputs            []types.NamedOutput
+	Secrets            []types.NamedSecret
+	IsDispatchable     bool
+	DispatchUsage      string
+	DispatchRequest    string
+	DispatchInputs     []types.NamedInput
+	Jobs               Jobs
+	Sections           map[string]string
+}
+
+// WorkflowsData is the model given to workflows templates.
+type WorkflowsData struct {
+	Workflows []*Data
+	Repo      repo.Info
+	Sections  map[string]string
+}
+
+func (w *Workflow) Data(r repo.Info) *Data {
+	inputs, outputs, secrets := w.getInputsOutputsSecrets()
+	return &Data{
+		Workflow:           w,
+		Name:               w.Name,
+		Title:              w.Title(),
+		Description:        w.Description,
+		Comment:            w.Comment,
+		File:               w.Filename,
+		Path:               r.Rel(w.Filename),
+		IsReusableWorkflow: w.IsReusableWorkflow,
+		Triggers:           w.Triggers(),
+		Usage:              w.Usage(),
+		Inputs:             inputs.List(),
+		Outputs:            outputs.List(),
+		Secrets:            secrets.List(),
+		IsDispatchable:     w.IsDispatchable(),
+		DispatchUsage:      w.DispatchUsage(),
+		DispatchRequest:    w.DispatchRequest(),
+		DispatchInputs:     w.getDispatchInputs().List(),
+		Jobs:               w.Jobs,
+		Sections:           sectionMap(w.Sections()),
+	}
+}
+
+func (w *Workflows) Data(r repo.Info) *WorkflowsData {
+	data := &WorkflowsData{
+		Workflows: []*Data{},
+		Repo:      r,
+		Sections:  sectionMap(w.index()),
+	}
+	for i := range w.Workflows {
+		data.Workflows = append(data.Workflows, w.Workflows[i].Data(r))
+	}
+	return data
+}
+
+// Render executes t with the workflows data, using the built-in layout when t is nil.
+func (w *Workflows) Render(t *template.Template, r repo.Info) (string, error) {
+	if t == nil {
+		var err error
+		if t, err = render.New("workflows", Template); err != nil {
+			return "", err
+		}
+	}
+	return render.Execute(t, w.Data(r))
+}
+
+func sectionMap(sections []*markdown.Section) map[string]string {
+	m := map[string]string{}
+	for _, section := range sections {
+		m[section.Name] = section.String()
+	}
+	return m
+}
@@ -0,0 +1,78 @@
+package workflow
+
+import (
+	"os"
+	"testing"
+
+	"github.com/nu12/action-docs/internal/render"
+	"github.com/nu12/action-docs/internal/repo"
+)
+
+func TestRender(t *testing.T) {
+	data := `
+name: 'Deploy'
+description: 'Deploys the application'
+on:
+  workflow_call:
+    inputs:
+      env:
+        description: 'Target environment'
+        type: string
+        required: true
+    secrets:
+      token:
+        required: true
+`
+	root := t.TempDir()
+	if err := os.MkdirAll(root+"/.github/workflows", 0755); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	file := root + "/.github/workflows/deploy.yml"
+	if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+
+	ws := Workflows{
+		Workflows: []Workflow{},
+	}
+	w, diagnostics := Parse(file)
+	if len(diagnostics) > 0 {
+		t.Fatalf("error: %v", diagnostics)
+	}
+	ws.AddWorkflow(w)
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "Built-in template",
+			expected: ws.Markdown(),
+		},
+		{
+			name:     "Custom template",
+			template: `{{ range .Workflows }}{{ h2 .Name }}{{ .Path }}{{ range .Inputs }} {{ .Name }}:{{ .Type }}{{ end }}{{ range .Secrets }} secrets.{{ .Name }}{{ end }}{{ end }}`,
+			expected: "## Deploy\n\n.github/workflows/deploy.yml env:string secrets.token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := render.New(tt.name, tt.template)
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if tt.template == "" {
+				parsed = nil
+			}
+			got, err := ws.Render(parsed, repo.Info{Root: root})
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf(errorf, "Template doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package workflow
+
+import (
+	"strings"
+
+	"github.com/nu12/action-docs/internal/cron"
+	"github.com/nu12/action-docs/internal/markdown"
+	"gopkg.in/yaml.v3"
+)
+
+// StringList is a list of strings that can also be written as a single one.
+type StringList []string
+
+func (l *StringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var s string
+		if err := node.Decode(&s); err != nil {
+			return err
+		}
+		*l = StringList{s}
+		return nil
+	}
+	var s []string
+	err := node.Decode(&s)
+	*l = s
+	return err
+}
+
+// Filter holds the activity types and the filters of push and pull_request
+// triggers.
+type Filter struct {
+	Types          StringList `yaml:"types" json:"types,omitempty"`
+	Branches       StringList `yaml:"branches" json:"branches,omitempty"`
+	BranchesIgnore StringList `yaml:"branches-ignore" json:"branches-ignore,omitempty"`
+	Tags           StringList `yaml:"tags" json:"tags,omitempty"`
+	TagsIgnore     StringList `yaml:"tags-ignore" json:"tags-ignore,omitempty"`
+	Paths          StringList `yaml:"paths" json:"paths,omitempty"`
+	PathsIgnore    StringList `yaml:"paths-ignore" json:"paths-ignore,omitempty"`
+}
+
+type Schedule struct {
+	Cron string `yaml:"cron" json:"cron"`
+}
+
+type WorkflowRun struct {
+	Workflows      StringList `yaml:"workflows" json:"workflows,omitempty"`
+	Types          StringList `yaml:"types" json:"types,omitempty"`
+	Branches       StringList `yaml:"branches" json:"branches,omitempty"`
+	BranchesIgnore StringList `yaml:"branches-ignore" json:"branches-ignore,omitempty"`
+}
+
+type RepositoryDispatch struct {
+	Types StringList `yaml:"types" json:"types,omitempty"`
+}
+
+// Triggers describes every trigger of the workflow, in the order of the file.
+func (w *Workflow) Triggers() []string {
+	var triggers []string
+	for _, event := range w.On.Events {
+		var details []string
+		switch event {
+		case "push":
+			details = w.On.Push.describe()
+		case "pull_request":
+			details = w.On.PullRequest.describe()
+		case "pull_request_target":
+			details = w.On.PullRequestTarget.describe()
+		case "schedule":
+			for _, schedule := range w.On.Schedule {
+				triggers = append(triggers, markdown.InlineCode(event)+": "+w.describeCron(schedule.Cron))
+			}
+			if len(w.On.Schedule) > 0 {
+				continue
+			}
+		case "workflow_run":
+			if run := w.On.WorkflowRun; run != nil {
+				details = describeFilters([]filter{
+					{"after", run.Workflows},
+					{"types", run.Types},
+					{"branches", run.Branches},
+					{"ignoring branches", run.BranchesIgnore},
+				})
+			}
+		case "repository_dispatch":
+			if dispatch := w.On.RepositoryDispatch; dispatch != nil {
+				details = describeFilters([]filter{{"types", dispatch.Types}})
+			}
+		}
+
+		trigger := markdown.InlineCode(event)
+		if len(details) > 0 {
+			trigger += ": " + strings.Join(details, "; ")
+		}
+		triggers = append(triggers, trigger)
+	}
+	return triggers
+}
+
+func (f *Filter) describe() []string {
+	if f == nil {
+		return nil
+	}
+	return describeFilters([]filter{
+		{"types", f.Types},
+		{"branches", f.Branches},
+		{"ignoring branches", f.BranchesIgnore},
+		{"tags", f.Tags},
+		{"ignoring tags", f.TagsIgnore},
+		{"paths", f.Paths},
+		{"ignoring paths", f.PathsIgnore},
+	})
+}
+
+type filter struct {
+	label  string
+	values StringList
+}
+
+// describeFilters describes the filters with values, e.g. "branches `main`".
+func describeFilters(filters []filter) []string {
+	var details []string
+	for _, f := range filters {
+		if len(f.values) == 0 {
+			continue
+		}
+		codes := make([]string, len(f.values))
+		for i, v := range f.values {
+			codes[i] = markdown.InlineCode(v)
+		}
+		details = append(details, f.label+" "+strings.Join(codes, ", "))
+	}
+	return details
+}
+
+// describeCron translates a cron expression, followed by its upcoming runs
+// when requested.
+func (w *Workflow) describeCron(expression string) string {
+	s, err := cron.Parse(expression)
+	if err != nil {
+		return markdown.InlineCode(expression) + " (invalid cron expression)"
+	}
+	description := markdown.InlineCode(expression) + " (" + s.String() + ")"
+	if runs := s.NextRuns(w.Options.Now, w.Options.NextRuns); len(runs) > 0 {
+		times := make([]string, len(runs))
+		for i, run := range runs {
+			times[i] = run.Format("2006-01-02 15:04")
+		}
+		description += ", next runs: " + strings.Join(times, ", ") + " UTC"
+	}
+	return description
+}
+
+func (w *Workflow) triggersSection() *markdown.Section {
+	section := markdown.NewSection("triggers")
+	triggers := w.Triggers()
+	if len(triggers) == 0 {
+		return section
+	}
+	list := markdown.List{}
+	for _, trigger := range triggers {
+		list.Add(trigger)
+	}
+	section.Add(markdown.H3("Triggers")).
+		Add(&list)
+	return section
+}
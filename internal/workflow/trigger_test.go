@@ -0,0 +1,107 @@
+package workflow
+
+import (
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/nu12/action-docs/internal/types"
+)
+
+func TestTriggers(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		options  types.Options
+		expected []string
+	}{
+		{
+			name: "Filters",
+			data: `on:
+  push:
+    branches: [main, 'release/**']
+    tags: v*
+    paths-ignore:
+    - docs/**
+  pull_request:
+    types: [opened, synchronize]
+  workflow_dispatch:
+`,
+			expected: []string{
+				"`push`: branches `main`, `release/**`; tags `v*`; ignoring paths `docs/**`",
+				"`pull_request`: types `opened`, `synchronize`",
+				"`workflow_dispatch`",
+			},
+		},
+		{
+			name: "Backticks",
+			data: "on:\n  push:\n    paths: ['docs/`a`.md']\n",
+			expected: []string{
+				"`push`: paths ``docs/`a`.md``",
+			},
+		},
+		{
+			name: "Schedules",
+			data: `on:
+  schedule:
+  - cron: '0 3 * * 1'
+  - cron: '*/30 * * * *'
+  - cron: 'not a cron'
+`,
+			expected: []string{
+				"`schedule`: `0 3 * * 1` (every Monday at 03:00 UTC)",
+				"`schedule`: `*/30 * * * *` (every 30 minutes)",
+				"`schedule`: `not a cron` (invalid cron expression)",
+			},
+		},
+		{
+			name: "Next runs",
+			data: `on:
+  schedule:
+  - cron: '0 3 * * 1'
+`,
+			options: types.Options{NextRuns: 2, Now: time.Date(2024, time.March, 4, 3, 0, 0, 0, time.UTC)},
+			expected: []string{
+				"`schedule`: `0 3 * * 1` (every Monday at 03:00 UTC), next runs: 2024-03-11 03:00, 2024-03-18 03:00 UTC",
+			},
+		},
+		{
+			name: "Other workflows and repository events",
+			data: `on:
+  workflow_run:
+    workflows: [Build]
+    types: [completed]
+    branches: [main]
+  repository_dispatch:
+    types: deploy
+`,
+			expected: []string{
+				"`workflow_run`: after `Build`; types `completed`; branches `main`",
+				"`repository_dispatch`: types `deploy`",
+			},
+		},
+		{
+			name:     "List of events",
+			data:     "on: [push, pull_request]\n",
+			expected: []string{"`push`", "`pull_request`"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := t.TempDir() + "/workflow.yml"
+			if err := os.WriteFile(file, []byte(tt.data), 0644); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			w, diagnostics := Parse(file)
+			if len(diagnostics) > 0 {
+				t.Fatalf("error: %v", diagnostics)
+			}
+			w.Options = tt.options
+			if got := w.Triggers(); !slices.Equal(got, tt.expected) {
+				t.Errorf(errorf, "Triggers don't match", tt.expected, got)
+			}
+		})
+	}
+}
@@ -3,145 +3,255 @@ package workflow
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
-	"strings"
 
+	"github.com/nu12/action-docs/internal/diagnostic"
 	"github.com/nu12/action-docs/internal/markdown"
+	"github.com/nu12/action-docs/internal/repo"
 	"github.com/nu12/action-docs/internal/types"
-	"github.com/nu12/go-logging"
 	"gopkg.in/yaml.v3"
 )
 
 type Workflow struct {
-	Name        string `yaml:"name"`
-	Description string `yaml:"description"`
-	On          struct {
-		WorkflowCall *struct {
-			Inputs  *types.InputMap  `yaml:"inputs"`
-			Outputs *types.OutputMap `yaml:"outputs"`
-			Secrets *types.SecretMap `yaml:"secrets"`
-		} `yaml:"workflow_call"`
-		WorkflowDispatch *struct {
-			Inputs *types.InputMap `yaml:"inputs"`
-		} `yaml:"workflow_dispatch"`
-	}
-	Filename           string
-	IsReusableWorkflow bool
+	Name               string        `yaml:"name" json:"name"`
+	Description        string        `yaml:"description" json:"description"`
+	On                 On            `json:"on"`
+	Jobs               Jobs          `yaml:"jobs" json:"jobs,omitempty"`
+	Filename           string        `json:"file"`
+	IsReusableWorkflow bool          `json:"isReusableWorkflow"`
+	Comment            string        `yaml:"-" json:"comment,omitempty"`
+	Options            types.Options `yaml:"-" json:"-"`
+	Repo               repo.Info     `yaml:"-" json:"-"`
+}
+
+// On holds the triggers of a workflow. Events lists every trigger in the
+// order of the file, whichever form of `on` declares them.
+type On struct {
+	Events             []string            `yaml:"-" json:"events"`
+	WorkflowCall       *WorkflowCall       `yaml:"workflow_call" json:"workflow_call,omitempty"`
+	WorkflowDispatch   *WorkflowDispatch   `yaml:"workflow_dispatch" json:"workflow_dispatch,omitempty"`
+	Push               *Filter             `yaml:"push" json:"push,omitempty"`
+	PullRequest        *Filter             `yaml:"pull_request" json:"pull_request,omitempty"`
+	PullRequestTarget  *Filter             `yaml:"pull_request_target" json:"pull_request_target,omitempty"`
+	Schedule           []Schedule          `yaml:"schedule" json:"schedule,omitempty"`
+	WorkflowRun        *WorkflowRun        `yaml:"workflow_run" json:"workflow_run,omitempty"`
+	RepositoryDispatch *RepositoryDispatch `yaml:"repository_dispatch" json:"repository_dispatch,omitempty"`
+}
+
+// UnmarshalYAML accepts a single event (`on: push`), a list of events
+// (`on: [push, workflow_dispatch]`) and a map of events to their settings.
+func (o *On) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		var event string
+		if err := node.Decode(&event); err != nil {
+			return err
+		}
+		*o = On{Events: []string{event}}
+		return nil
+	case yaml.SequenceNode:
+		var events []string
+		if err := node.Decode(&events); err != nil {
+			return err
+		}
+		*o = On{Events: events}
+		return nil
+	}
+
+	type plain On
+	var p plain
+	err := node.Decode(&p)
+	*o = On(p)
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i < len(node.Content); i += 2 {
+			o.Events = append(o.Events, node.Content[i].Value)
+		}
+	}
+	return err
+}
+
+// Has reports whether the workflow is triggered by event.
+func (o *On) Has(event string) bool {
+	return slices.Contains(o.Events, event)
+}
+
+type WorkflowCall struct {
+	Inputs  *types.InputMap  `yaml:"inputs" json:"inputs"`
+	Outputs *types.OutputMap `yaml:"outputs" json:"outputs"`
+	Secrets *types.SecretMap `yaml:"secrets" json:"secrets"`
+}
+
+type WorkflowDispatch struct {
+	Inputs *types.InputMap `yaml:"inputs" json:"inputs"`
 }
 
 func (w *Workflow) Markdown() string {
-	inputs, outputs, secrets := w.getInputsOutputsSecrets()
-	md := &markdown.Markdown{}
-	md.Add(markdown.H2(w.Name)).
+	s := ""
+	for _, section := range w.Sections() {
+		s += section.String()
+	}
+	return s
+}
+
+// Sections returns the header and the triggers of the workflow followed by
+// the sections of the interfaces it declares: "call" and its inputs, outputs and secrets for
+// reusable workflows, "dispatch" and its inputs for manual runs, and finally
+// its jobs.
+func (w *Workflow) Sections() []*markdown.Section {
+	header := markdown.NewSection("header")
+	header.Add(markdown.H2(w.Title())).
 		Add(markdown.P("File: " + w.Filename)).
-		Add(markdown.P(w.Description))
+		Add(markdown.P(w.Options.Describe(w.Description, w.Comment)))
 
+	call := markdown.NewSection("call")
+	callInputs := markdown.NewSection("call-inputs")
+	callOutputs := markdown.NewSection("call-outputs")
+	callSecrets := markdown.NewSection("call-secrets")
 	if w.IsReusableWorkflow {
-		md.Add(markdown.H3("Usage example")).
-			Add(markdown.Code(fmt.Sprintf("name: My workflow\non:\n  push:\n    branches:\n    - main\n\njobs:\n  my-job:\n    uses: %s@main\n%s", w.Filename, inputs.ToString(6))))
-	}
+		inputs, outputs, secrets := w.getInputsOutputsSecrets()
 
-	if len(*inputs) > 0 {
-		md.Add(markdown.H3("Inputs"))
+		call.Add(markdown.H3("Reusable workflow")).
+			Add(markdown.H4("Usage example")).
+			Add(markdown.Code(w.Usage()))
 
-		if w.IsReusableWorkflow {
+		if len(*inputs) > 0 {
 			tInputs := markdown.Table{
-				Header: markdown.Header{"Name", "Type", "Description", "Required"},
+				Header:   markdown.Header{"Name", "Type", "Description", "Required", "Default"},
+				Verbatim: []int{4},
+				NewLine:  w.Options.NewLine,
 			}
 			for name, input := range *inputs {
-				tInputs.AddRow(markdown.Row{name, input.Type, input.Description, strconv.FormatBool(input.Required)})
+				tInputs.AddRow(markdown.Row{name, input.Type, w.Options.Describe(input.Description, input.Comment), strconv.FormatBool(input.Required), input.Default.Markdown(w.Options.CodeDefaults)})
 			}
+			callInputs.Add(markdown.H4("Inputs")).
+				Add(tInputs.Sort(0))
+		}
 
-			md.Add(tInputs.Sort(0))
-		} else {
-			in := markdown.Table{
-				Header: markdown.Header{"Name", "Type", "Description", "Default"},
+		if len(*outputs) > 0 {
+			tOutputs := markdown.Table{
+				Header:  markdown.Header{"Name", "Description"},
+				NewLine: w.Options.NewLine,
 			}
-			for name, input := range *inputs {
-				in.AddRow(markdown.Row{name, input.Type, input.Description, input.Default})
+			for name, output := range *outputs {
+				tOutputs.AddRow(markdown.Row{name, w.Options.Describe(output.Description, output.Comment)})
 			}
+			callOutputs.Add(markdown.H4("Outputs")).
+				Add(tOutputs.Sort(0))
+		}
 
-			md.Add(in.Sort(0))
+		if len(*secrets) > 0 {
+			tSecrets := markdown.Table{
+				Header:  markdown.Header{"Name", "Description", "Required"},
+				NewLine: w.Options.NewLine,
+			}
+			for name, secret := range *secrets {
+				tSecrets.AddRow(markdown.Row{name, w.Options.Describe(secret.Description, secret.Comment), strconv.FormatBool(secret.Required)})
+			}
+			callSecrets.Add(markdown.H4("Secrets")).
+				Add(tSecrets.Sort(0))
 		}
 	}
 
-	if len(*outputs) > 0 {
-		md.Add(markdown.H3("Outputs"))
+	dispatch := markdown.NewSection("dispatch")
+	dispatchInputs := markdown.NewSection("dispatch-inputs")
+	if w.IsDispatchable() {
+		inputs := w.getDispatchInputs()
 
-		tOutputs := markdown.Table{
-			Header: markdown.Header{"Name", "Description"},
-		}
-		for name, output := range *w.On.WorkflowCall.Outputs {
-			tOutputs.AddRow(markdown.Row{name, output.Description})
-		}
+		dispatch.Add(markdown.H3("Manual run")).
+			Add(markdown.H4("Usage example")).
+			Add(markdown.P("With the GitHub CLI:")).
+			Add(markdown.Code(w.DispatchUsage())).
+			Add(markdown.P("With the REST API:")).
+			Add(markdown.Code(w.DispatchRequest()))
 
-		md.Add(tOutputs.Sort(0))
+		if len(*inputs) > 0 {
+			tInputs := markdown.Table{
+				Header:   markdown.Header{"Name", "Type", "Description", "Default", "Allowed values"},
+				Verbatim: []int{3, 4},
+				NewLine:  w.Options.NewLine,
+			}
+			for name, input := range *inputs {
+				tInputs.AddRow(markdown.Row{name, input.Type, w.Options.Describe(input.Description, input.Comment), input.Default.Markdown(w.Options.CodeDefaults), input.Allowed()})
+			}
+			dispatchInputs.Add(markdown.H4("Inputs")).
+				Add(tInputs.Sort(0))
+		}
 	}
 
-	if len(*secrets) > 0 {
-		md.Add(markdown.H3("Secrets"))
-
-		tSecrets := markdown.Table{
-			Header: markdown.Header{"Name", "Required"},
-		}
-		for name, secret := range *w.On.WorkflowCall.Secrets {
-			tSecrets.AddRow(markdown.Row{name, strconv.FormatBool(secret.Required)})
-		}
+	return []*markdown.Section{header, w.triggersSection(), call, callInputs, callOutputs, callSecrets, dispatch, dispatchInputs, w.jobsSection()}
+}
 
-		md.Add(tSecrets.Sort(0))
+// Title returns the name of the workflow, or its file when it has none.
+func (w *Workflow) Title() string {
+	if w.Name == "" {
+		return w.Filename
 	}
+	return w.Name
+}
 
-	return md.String()
+// Usage returns an example of a job calling the workflow, empty for workflows
+// that aren't reusable.
+func (w *Workflow) Usage() string {
+	if !w.IsReusableWorkflow {
+		return ""
+	}
+	return fmt.Sprintf("name: My workflow\non:\n  push:\n    branches:\n    - main\n\njobs:\n  my-job:\n    uses: %s\n%s", w.Repo.Uses(w.Filename), w.getInputs().ToString(6))
 }
 
-func Parse(file string, log *logging.Log) *Workflow {
+// Parse reads a workflow file. Diagnostics are returned for unreadable or
+// invalid files, along with whatever could be decoded.
+func Parse(file string) (*Workflow, diagnostic.List) {
 	w := &Workflow{
-		On: struct {
-			WorkflowCall *struct {
-				Inputs  *types.InputMap  `yaml:"inputs"`
-				Outputs *types.OutputMap `yaml:"outputs"`
-				Secrets *types.SecretMap `yaml:"secrets"`
-			} `yaml:"workflow_call"`
-			WorkflowDispatch *struct {
-				Inputs *types.InputMap `yaml:"inputs"`
-			} `yaml:"workflow_dispatch"`
-		}{
-			WorkflowCall: &struct {
-				Inputs  *types.InputMap  `yaml:"inputs"`
-				Outputs *types.OutputMap `yaml:"outputs"`
-				Secrets *types.SecretMap `yaml:"secrets"`
-			}{
-				Inputs:  &types.InputMap{},
-				Outputs: &types.OutputMap{},
-				Secrets: &types.SecretMap{},
-			},
-			WorkflowDispatch: &struct {
-				Inputs *types.InputMap `yaml:"inputs"`
-			}{
-				Inputs: &types.InputMap{},
-			},
-		},
-		IsReusableWorkflow: false,
+		Filename: file,
 	}
 
 	b, err := os.ReadFile(file)
 	if err != nil {
-		log.Warning(err.Error())
-		return w
+		return w, diagnostic.FromError(file, err)
 	}
 
-	err = yaml.Unmarshal([]byte(b), w)
-	if err != nil {
-		log.Warning(err.Error())
+	var diagnostics diagnostic.List
+	var root yaml.Node
+	if err := yaml.Unmarshal(b, &root); err != nil {
+		diagnostics = diagnostic.FromError(file, err)
+	} else {
+		w.Comment = types.FileComment(&root)
+		if err := root.Decode(w); err != nil {
+			diagnostics = diagnostic.FromError(file, err)
+		}
 	}
-	w.Filename = file
 
-	if strings.Contains(string(b), "workflow_call") {
-		w.IsReusableWorkflow = true
+	w.On.normalize()
+	w.IsReusableWorkflow = w.On.Has("workflow_call")
+	return w, diagnostics
+}
+
+// normalize makes sure the declared interfaces and their maps are never nil.
+func (o *On) normalize() {
+	if o.WorkflowCall == nil && o.Has("workflow_call") {
+		o.WorkflowCall = &WorkflowCall{}
+	}
+	if o.WorkflowDispatch == nil && o.Has("workflow_dispatch") {
+		o.WorkflowDispatch = &WorkflowDispatch{}
+	}
+	if o.WorkflowCall != nil {
+		if o.WorkflowCall.Inputs == nil {
+			o.WorkflowCall.Inputs = &types.InputMap{}
+		}
+		if o.WorkflowCall.Outputs == nil {
+			o.WorkflowCall.Outputs = &types.OutputMap{}
+		}
+		if o.WorkflowCall.Secrets == nil {
+			o.WorkflowCall.Secrets = &types.SecretMap{}
+		}
+	}
+	if o.WorkflowDispatch != nil && o.WorkflowDispatch.Inputs == nil {
+		o.WorkflowDispatch.Inputs = &types.InputMap{}
 	}
-	return w
 }
 
+// getInputs returns the inputs of the workflow_call interface.
 func (w *Workflow) getInputs() *types.InputMap {
 	if w.IsReusableWorkflow {
 		if w.On.WorkflowCall.Inputs == nil {
@@ -150,6 +260,11 @@ func (w *Workflow) getInputs() *types.InputMap {
 		w.On.WorkflowCall.Inputs.Sort()
 		return w.On.WorkflowCall.Inputs
 	}
+	return &types.InputMap{}
+}
+
+// getDispatchInputs returns the inputs of the workflow_dispatch interface.
+func (w *Workflow) getDispatchInputs() *types.InputMap {
 	if w.On.WorkflowDispatch == nil {
 		return &types.InputMap{}
 	}
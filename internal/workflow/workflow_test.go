@@ -2,11 +2,13 @@ package workflow
 
 import (
 	"os"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/nu12/action-docs/internal/helper"
+	"github.com/nu12/action-docs/internal/repo"
 	"github.com/nu12/action-docs/internal/types"
-	"github.com/nu12/go-logging"
 )
 
 const errorf = "Error: %v. \nExpected: %v \nGot: %v"
@@ -22,6 +24,7 @@ func TestWorkflow(t *testing.T) {
 		expectedDescription        string
 		expectedHash               string
 		expectedInputs             *types.InputMap
+		expectedDispatchInputs     *types.InputMap
 		expectedOutputs            *types.OutputMap
 		expectedSecrets            *types.SecretMap
 	}{
@@ -51,7 +54,7 @@ on:
 			expectedIsReusableWorkflow: true,
 			expectedName:               "Workflow name 1",
 			expectedDescription:        "Workflow description 1",
-			expectedHash:               "0e6209aadcd84a36add6dac6aaacc09f",
+			expectedHash:               "cc7d881a23f6965cb2c276b1a1bdfb0c",
 			expectedInputs: &types.InputMap{
 				"in1": {Description: "Input1", Required: true},
 				"in2": {Description: "Input2", Required: false},
@@ -83,9 +86,42 @@ on:
 			expectedIsReusableWorkflow: false,
 			expectedName:               "Workflow name 2",
 			expectedDescription:        "Workflow description 2",
-			expectedHash:               "8721bc3d2f959c9a5fe825d60eb70514",
+			expectedHash:               "848299553fff82d115c67b734f34361a",
+			expectedInputs:             &types.InputMap{},
+			expectedDispatchInputs: &types.InputMap{
+				"in1": {Description: "Input1", Type: "choice", Default: types.Default{Value: "one", Kind: types.KindString}, Options: []string{"one", "two"}},
+			},
+			expectedOutputs: &types.OutputMap{},
+			expectedSecrets: &types.SecretMap{},
+		},
+		{
+			name: "Workflow call and dispatch",
+			data: `
+name: 'Workflow name 5'
+description: 'Workflow description 5'
+on:
+  workflow_call:
+    inputs:
+      in1:
+        description: 'Input1'
+        required: true
+  workflow_dispatch:
+    inputs:
+      in2:
+        description: 'Input2'
+        type: boolean
+        default: true
+`,
+			expectedFilename:           "both.yml",
+			expectedIsReusableWorkflow: true,
+			expectedName:               "Workflow name 5",
+			expectedDescription:        "Workflow description 5",
+			expectedHash:               "8490957d256599f2e24a7a946066f8d5",
 			expectedInputs: &types.InputMap{
-				"in1": {Description: "Input1", Type: "choice", Default: "one"},
+				"in1": {Description: "Input1", Required: true},
+			},
+			expectedDispatchInputs: &types.InputMap{
+				"in2": {Description: "Input2", Type: "boolean", Default: types.Default{Value: "true", Kind: types.KindBool}},
 			},
 			expectedOutputs: &types.OutputMap{},
 			expectedSecrets: &types.SecretMap{},
@@ -102,7 +138,7 @@ on:
 			expectedIsReusableWorkflow: true,
 			expectedName:               "Workflow name 3",
 			expectedDescription:        "Workflow description 3",
-			expectedHash:               "696b5d1e79c3dbff8476ec1dc47643e6",
+			expectedHash:               "5a1b66be9b927c6c7badaf0770964811",
 			expectedInputs:             &types.InputMap{},
 			expectedOutputs:            &types.OutputMap{},
 			expectedSecrets:            &types.SecretMap{},
@@ -119,14 +155,13 @@ on:
 			expectedIsReusableWorkflow: false,
 			expectedName:               "Workflow name 4",
 			expectedDescription:        "Workflow description 4",
-			expectedHash:               "a90ad004f3a963f718a317ba6dd55f0f",
+			expectedHash:               "94513b130a8bdbbd1227a13e08694dec",
 			expectedInputs:             &types.InputMap{},
 			expectedOutputs:            &types.OutputMap{},
 			expectedSecrets:            &types.SecretMap{},
 		},
 	}
 
-	log := logging.NewLogger()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			dir := t.TempDir()
@@ -138,7 +173,10 @@ on:
 			defer os.Remove(tmpFile)
 
 			// Parse
-			w := Parse(tmpFile, log)
+			w, diagnostics := Parse(tmpFile)
+			if len(diagnostics) > 0 {
+				t.Fatalf("error: %v", diagnostics)
+			}
 			w.Filename = tt.expectedFilename
 			// Check name
 			if w.Name != tt.expectedName {
@@ -167,6 +205,14 @@ on:
 				t.Errorf(errorf, "Inputs don't match", tt.expectedInputs, inputs)
 			}
 
+			// Check dispatch inputs
+			if tt.expectedDispatchInputs == nil {
+				tt.expectedDispatchInputs = &types.InputMap{}
+			}
+			if !w.getDispatchInputs().Equals(tt.expectedDispatchInputs) {
+				t.Errorf(errorf, "Dispatch inputs don't match", tt.expectedDispatchInputs, w.getDispatchInputs())
+			}
+
 			// Check outputs
 			if !outputs.Equals(tt.expectedOutputs) {
 				t.Errorf(errorf, "Outputs don't match", tt.expectedOutputs, outputs)
@@ -188,3 +234,142 @@ on:
 	}
 
 }
+
+func TestOn(t *testing.T) {
+	tests := []struct {
+		name                       string
+		data                       string
+		expectedEvents             []string
+		expectedIsReusableWorkflow bool
+		expectedIsDispatchable     bool
+	}{
+		{
+			name:                       "Single event",
+			data:                       "on: workflow_call\n",
+			expectedEvents:             []string{"workflow_call"},
+			expectedIsReusableWorkflow: true,
+		},
+		{
+			name:                   "List of events",
+			data:                   "on: [push, workflow_dispatch]\n",
+			expectedEvents:         []string{"push", "workflow_dispatch"},
+			expectedIsDispatchable: true,
+		},
+		{
+			name:                       "Map of events",
+			data:                       "on:\n  pull_request:\n    branches: [main]\n  workflow_call:\n  workflow_dispatch: {}\n",
+			expectedEvents:             []string{"pull_request", "workflow_call", "workflow_dispatch"},
+			expectedIsReusableWorkflow: true,
+			expectedIsDispatchable:     true,
+		},
+		{
+			name:           "Mentioned outside of the triggers",
+			data:           "# Not a workflow_call\non: push\njobs:\n  job:\n    steps:\n    - run: echo workflow_call\n",
+			expectedEvents: []string{"push"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := t.TempDir() + "/workflow.yml"
+			if err := os.WriteFile(file, []byte(tt.data), 0644); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			w, diagnostics := Parse(file)
+			if len(diagnostics) > 0 {
+				t.Fatalf("error: %v", diagnostics)
+			}
+			if !slices.Equal(w.On.Events, tt.expectedEvents) {
+				t.Errorf(errorf, "Events don't match", tt.expectedEvents, w.On.Events)
+			}
+			if w.IsReusableWorkflow != tt.expectedIsReusableWorkflow {
+				t.Errorf(errorf, "IsReusableWorkflow doesn't match", tt.expectedIsReusableWorkflow, w.IsReusableWorkflow)
+			}
+			if w.IsDispatchable() != tt.expectedIsDispatchable {
+				t.Errorf(errorf, "IsDispatchable doesn't match", tt.expectedIsDispatchable, w.IsDispatchable())
+			}
+		})
+	}
+}
+
+func TestUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		repo     repo.Info
+		expected string
+	}{
+		{name: "Unknown repository", expected: "    uses: .github/workflows/call.yml@main\n"},
+		{name: "Repository", repo: repo.Info{Slug: "acme/tools", Ref: "v1.2.3"}, expected: "    uses: acme/tools/.github/workflows/call.yml@v1.2.3\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Workflow{
+				Filename:           ".github/workflows/call.yml",
+				IsReusableWorkflow: true,
+				On:                 On{WorkflowCall: &WorkflowCall{Inputs: &types.InputMap{}}},
+				Repo:               tt.repo,
+			}
+			if got := w.Usage(); !strings.HasSuffix(got, tt.expected) {
+				t.Errorf(errorf, "Usage doesn't match", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestTableOptions(t *testing.T) {
+	data := `
+name: Tables
+on:
+  workflow_call:
+    inputs:
+      name:
+        type: string
+        description: |
+          first
+          second
+        default: |
+          <a>
+          b
+    secrets:
+      token:
+        description: "a | b"
+  workflow_dispatch:
+    inputs:
+      script:
+        description: Script to run
+        default: |
+          echo <a>
+          echo b
+`
+	file := t.TempDir() + "/tables.yml"
+	if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	w, diagnostics := Parse(file)
+	if len(diagnostics) > 0 {
+		t.Fatalf("error: %v", diagnostics)
+	}
+	w.Options = types.Options{NewLine: " "}
+
+	sections := map[string]string{}
+	for _, section := range w.Sections() {
+		sections[section.Name] = section.String()
+	}
+	tests := []struct {
+		section  string
+		expected string
+	}{
+		{"call-inputs", "|name|string|first second|false|<pre>&lt;a&gt;<br>b</pre>|\n"},
+		{"call-secrets", "|token|a \\| b|false|\n"},
+		{"dispatch-inputs", "|script||Script to run|<pre>echo &lt;a&gt;<br>echo b</pre>||\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.section, func(t *testing.T) {
+			if !strings.Contains(sections[tt.section], tt.expected) {
+				t.Errorf(errorf, "Row doesn't match", tt.expected, sections[tt.section])
+			}
+		})
+	}
+}
@@ -1,37 +1,106 @@
 package workflow
 
 import (
-	"github.com/nu12/action-docs/internal/helper"
+	"path/filepath"
+	"strings"
+
 	"github.com/nu12/action-docs/internal/markdown"
 )
 
 type Workflows struct {
 	Workflows []Workflow
-	Content   markdown.List
+	// Anchors overrides the anchors of the sections, by section name (e.g.
+	// "deploy.yml:header"), when the README they are injected into has
+	// hand-written headings too.
+	Anchors map[string]string
 }
 
 func (w *Workflows) AddWorkflow(workflow *Workflow) *Workflows {
 	w.Workflows = append(w.Workflows, *workflow)
-
-	link := markdown.Hyperlink{
-		Text: workflow.Filename,
-		URL:  "#" + helper.SanitizeURL(workflow.Name),
-	}
-	w.Content.Add(link.String())
 	return w
 }
 
 func (w *Workflows) Markdown() string {
-	var body = ""
+	s := ""
+	for _, section := range w.Sections() {
+		s += section.String()
+	}
+	return s
+}
+
+// Sections returns the table of contents followed by the sections of every
+// workflow, named after the workflow file (e.g. "deploy.yml:inputs").
+func (w *Workflows) Sections() []*markdown.Section {
+	sections := w.index()
 	for _, workflow := range w.Workflows {
-		body += workflow.Markdown()
+		for _, section := range workflow.Sections() {
+			section.Name = filepath.Base(workflow.Filename) + ":" + section.Name
+			sections = append(sections, section)
+		}
 	}
-	header := (&markdown.Markdown{
-		Elements: []markdown.Element{
-			markdown.H1("Workflows"),
-			markdown.P("Table of contents:"),
-			&w.Content,
-		},
-	}).String()
-	return header + body
+	return sections
+}
+
+// index returns the sections preceding the workflows.
+func (w *Workflows) index() []*markdown.Section {
+	header := markdown.NewSection("header")
+	header.Add(markdown.H1(title))
+
+	toc := markdown.NewSection("toc")
+	toc.Add(markdown.P("Table of contents:")).
+		Add(w.contents())
+
+	return []*markdown.Section{header, toc}
 }
+
+// title is the heading of the workflows documentation.
+const title = "Workflows"
+
+// contents returns the table of contents, linking every workflow by its title
+// to its heading.
+func (w *Workflows) contents() *markdown.Table {
+	table := markdown.Table{
+		Header:   markdown.Header{"Workflow", "Triggers"},
+		Verbatim: []int{0, 1},
+	}
+	for i, anchor := range w.anchors() {
+		workflow := w.Workflows[i]
+		link := markdown.Hyperlink{
+			Text: linkEscaper.Replace(workflow.Title()),
+			URL:  "#" + anchor,
+		}
+		events := make([]string, len(workflow.On.Events))
+		for i, event := range workflow.On.Events {
+			events[i] = markdown.InlineCode(event)
+		}
+		table.AddRow(markdown.Row{link.String(), strings.Join(events, ", ")})
+	}
+	return &table
+}
+
+// anchors returns the anchor of the heading of every workflow. Anchors are
+// generated for every heading of the document, in order, so that repeated
+// headings are numbered as GitHub does, unless Anchors has the anchor of the
+// heading.
+func (w *Workflows) anchors() []string {
+	slugger := markdown.Slugger{}
+	slugger.Slug(title)
+	anchors := make([]string, len(w.Workflows))
+	for i, workflow := range w.Workflows {
+		for j, section := range workflow.Sections() {
+			for k, heading := range section.Headings() {
+				anchor := slugger.Slug(heading)
+				if j == 0 && k == 0 {
+					anchors[i] = anchor
+					if a, ok := w.Anchors[filepath.Base(workflow.Filename)+":"+section.Name]; ok {
+						anchors[i] = a
+					}
+				}
+			}
+		}
+	}
+	return anchors
+}
+
+// linkEscaper escapes the text of a link.
+var linkEscaper = strings.NewReplacer("\\", "\\\\", "[", "\\[", "]", "\\]", "<", "&lt;", ">", "&gt;")
@@ -4,7 +4,8 @@ import (
 	"testing"
 
 	"github.com/nu12/action-docs/internal/helper"
-	"github.com/nu12/action-docs/internal/markdown"
+	"github.com/nu12/action-docs/internal/repo"
+	"github.com/nu12/action-docs/internal/types"
 )
 
 func TestWorkflows(t *testing.T) {
@@ -22,7 +23,7 @@ func TestWorkflows(t *testing.T) {
 					Filename:    ".github/workflows/a.yml",
 				},
 			},
-			expectedHash: "7e74e2d88376bf641107fe22ea09ce68",
+			expectedHash: "f65176ae4a97985ef753034e5f2ed4a1",
 		},
 		{
 			name: "Two workflows",
@@ -38,7 +39,7 @@ func TestWorkflows(t *testing.T) {
 					Filename:    ".github/workflows/b.yml",
 				},
 			},
-			expectedHash: "e6b8867c16847397e0eab0d694a39526",
+			expectedHash: "f520a5830c22c8323763a0f1beba05b8",
 		},
 	}
 
@@ -46,15 +47,14 @@ func TestWorkflows(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			ws := Workflows{
 				Workflows: []Workflow{},
-				Content:   markdown.List{},
 			}
 			for _, w := range tt.given {
 				ws.AddWorkflow(&w)
 			}
 
 			// Check # of items in the list
-			if len(ws.Content.Items) != len(tt.given) {
-				t.Errorf(errorf, "contents size mismatch", len(tt.given), len(ws.Content.Items))
+			if len(ws.contents().Rows) != len(tt.given) {
+				t.Errorf(errorf, "contents size mismatch", len(tt.given), len(ws.contents().Rows))
 			}
 
 			// Check # of workflows
@@ -67,6 +67,54 @@ func TestWorkflows(t *testing.T) {
 				t.Errorf(errorf, "mismatch", tt.expectedHash, helper.Hash(ws.Markdown()))
 				t.Error(ws.Markdown())
 			}
+
+			// Built-in template matches the markdown
+			rendered, err := ws.Render(nil, repo.Info{})
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if rendered != ws.Markdown() {
+				t.Errorf(errorf, "Default template doesn't match", ws.Markdown(), rendered)
+			}
 		})
 	}
 }
+
+func TestContents(t *testing.T) {
+	inputs := types.InputMap{"env": {Type: "string"}}
+	ws := Workflows{}
+	ws.AddWorkflow(&Workflow{Name: "CI / Build (v1.2) 🚀", Filename: "ci.yml", On: On{Events: []string{"push"}}})
+	ws.AddWorkflow(&Workflow{Name: "Deploy", Filename: "deploy.yml"})
+	ws.AddWorkflow(&Workflow{
+		Name:               "Deploy",
+		Filename:           "deploy-again.yml",
+		IsReusableWorkflow: true,
+		On: On{
+			Events:       []string{"workflow_call"},
+			WorkflowCall: &WorkflowCall{Inputs: &inputs, Outputs: &types.OutputMap{}, Secrets: &types.SecretMap{}},
+		},
+	})
+	ws.AddWorkflow(&Workflow{Name: "Inputs", Filename: "inputs.yml"})
+	ws.AddWorkflow(&Workflow{Filename: "unnamed.yml"})
+
+	expected := "|Workflow|Triggers|\n|---|---|\n" +
+		"|[CI / Build (v1.2) 🚀](#ci--build-v12-)|`push`|\n" +
+		"|[Deploy](#deploy)||\n" +
+		"|[Deploy](#deploy-1)|`workflow_call`|\n" +
+		"|[Inputs](#inputs-1)||\n" +
+		"|[unnamed.yml](#unnamedyml)||\n\n"
+	if got := ws.contents().String(); got != expected {
+		t.Errorf(errorf, "Table of contents doesn't match", expected, got)
+	}
+}
+
+func TestContentsAnchors(t *testing.T) {
+	ws := Workflows{Anchors: map[string]string{"deploy.yml:header": "deploy-1"}}
+	ws.AddWorkflow(&Workflow{Name: "Deploy", Filename: ".github/workflows/deploy.yml"})
+	ws.AddWorkflow(&Workflow{Name: "CI", Filename: ".github/workflows/ci.yml"})
+
+	expected := "|Workflow|Triggers|\n|---|---|\n|[Deploy](#deploy-1)||\n|[CI](#ci)||\n\n"
+	if got := ws.contents().String(); got != expected {
+		t.Errorf(errorf, "Table of contents doesn't match", expected, got)
+	}
+}